@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -23,10 +25,28 @@ func ConnectDB() {
 		os.Getenv("DB_SSLMODE"),
 	)
 
+	attempts, interval := dbConnectRetry()
+	maxInterval := 30 * time.Second
+
 	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	})
+	for attempt := 1; attempt <= attempts; attempt++ {
+		DB, err = openDB(dsn)
+		if err == nil {
+			break
+		}
+
+		log.Printf("Database connection attempt %d/%d failed: %v", attempt, attempts, err)
+		if attempt < attempts {
+			log.Printf("Retrying database connection in %s", interval)
+			time.Sleep(interval)
+
+			// Back off exponentially, capped so retries keep happening regularly
+			interval *= 2
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
 
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
@@ -35,6 +55,45 @@ func ConnectDB() {
 	log.Println("Database connected successfully")
 }
 
+// openDB opens the database and verifies the connection with a ping
+func openDB(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+		// Report constraint violations as gorm.ErrDuplicatedKey etc.
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// dbConnectRetry returns the number of connection attempts (DB_CONNECT_ATTEMPTS,
+// default 10) and the initial wait between them (DB_CONNECT_INTERVAL, default 2s)
+func dbConnectRetry() (int, time.Duration) {
+	attempts := 10
+	if n, err := strconv.Atoi(GetEnv("DB_CONNECT_ATTEMPTS", "")); err == nil && n > 0 {
+		attempts = n
+	}
+
+	interval := 2 * time.Second
+	if d, err := time.ParseDuration(GetEnv("DB_CONNECT_INTERVAL", "")); err == nil && d > 0 {
+		interval = d
+	}
+
+	return attempts, interval
+}
+
 func GetDB() *gorm.DB {
 	return DB
 }
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MinJWTSecretLength is the shortest JWT_SECRET accepted at startup (256 bits)
+const MinJWTSecretLength = 32
+
+// requiredEnv lists the variables the server can't start without. Everything
+// else is optional and falls back to a default.
+var requiredEnv = []string{
+	"JWT_SECRET",
+	"DB_HOST",
+	"DB_PORT",
+	"DB_USER",
+	"DB_NAME",
+}
+
+// exampleJWTSecret is the placeholder shipped in .env.example
+const exampleJWTSecret = "your-super-secret-jwt-key-change-this-in-production"
+
+// ValidateEnv checks the required variables before anything connects or
+// listens, so a missing or weak setting stops the server with a clear message
+// instead of booting into an insecure or broken state. All problems are
+// reported together.
+func ValidateEnv() error {
+	var problems []error
+
+	// Required variables must be set
+	for _, key := range requiredEnv {
+		if strings.TrimSpace(os.Getenv(key)) == "" {
+			problems = append(problems, fmt.Errorf("%s is required", key))
+		}
+	}
+
+	// An empty or guessable secret lets anyone forge tokens
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		if len(secret) < MinJWTSecretLength {
+			problems = append(problems, fmt.Errorf("JWT_SECRET must be at least %d characters", MinJWTSecretLength))
+		}
+		if secret == exampleJWTSecret {
+			problems = append(problems, errors.New("JWT_SECRET is still the example value from .env.example"))
+		}
+	}
+
+	if port := os.Getenv("DB_PORT"); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			problems = append(problems, fmt.Errorf("DB_PORT must be a port number, got %q", port))
+		}
+	}
+
+	return errors.Join(problems...)
+}
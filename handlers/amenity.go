@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// amenitiesCacheKey caches the public list of active amenities
+const amenitiesCacheKey = "amenities:list"
+
+// prependAmenityImages adds BASE_URL to the image URLs of amenities
+func prependAmenityImages(amenities []models.Amenity) {
+	baseURL := config.GetEnv("BASE_URL", "")
+	for i := range amenities {
+		amenities[i].ImageURL = utils.PrependBaseURL(amenities[i].ImageURL, baseURL)
+	}
+}
+
+// GetAmenities retrieves the active amenities in display order
+func GetAmenities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Try to get from cache first
+	var amenities []models.Amenity
+	if err := cacheGet(ctx, r, amenitiesCacheKey, &amenities); err == nil {
+		prependAmenityImages(amenities)
+		utils.RespondList(w, r, "amenities", amenities, nil)
+		return
+	}
+
+	// Cache miss - get from database
+	db := config.GetDB()
+	if err := db.Where("active = ?", true).Order("sort_order ASC, name ASC").Find(&amenities).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Store in cache (without BASE_URL prepended)
+	_ = utils.CacheSet(ctx, amenitiesCacheKey, amenities, utils.CacheTTLAmenities)
+
+	prependAmenityImages(amenities)
+	utils.RespondList(w, r, "amenities", amenities, nil)
+}
+
+// GetAllAmenities retrieves every amenity, including inactive ones (admin only)
+func GetAllAmenities(w http.ResponseWriter, r *http.Request) {
+	var amenities []models.Amenity
+	db := config.GetDB()
+	if err := db.Order("sort_order ASC, name ASC").Find(&amenities).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	prependAmenityImages(amenities)
+	utils.RespondList(w, r, "amenities", amenities, nil)
+}
+
+// CreateAmenity creates a new amenity with an optional image
+func CreateAmenity(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form with max memory of 10MB
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondBadRequest(w, "Failed to parse form data")
+		return
+	}
+
+	amenity := models.Amenity{
+		Name:        r.FormValue("name"),
+		Description: r.FormValue("description"),
+		Icon:        r.FormValue("icon"),
+		Active:      true,
+	}
+
+	// Validate fields
+	fields := make(map[string]string)
+	if amenity.Name == "" {
+		fields["name"] = "Name is required"
+	}
+
+	db := config.GetDB()
+	if sortOrderStr := r.FormValue("sort_order"); sortOrderStr != "" {
+		sortOrder, err := strconv.Atoi(sortOrderStr)
+		if err != nil || sortOrder < 0 {
+			fields["sort_order"] = "Sort order must be a non-negative number"
+		}
+		amenity.SortOrder = sortOrder
+	} else {
+		// Append after the last amenity
+		var last models.Amenity
+		if err := db.Order("sort_order DESC").First(&last).Error; err == nil {
+			amenity.SortOrder = last.SortOrder + 1
+		}
+	}
+
+	if activeStr := r.FormValue("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			fields["active"] = "Active must be true or false"
+		}
+		amenity.Active = active
+	}
+
+	if len(fields) > 0 {
+		utils.RespondValidationError(w, fields)
+		return
+	}
+
+	// Save the image if one was uploaded
+	if file, header, err := r.FormFile("image"); err == nil {
+		defer file.Close()
+
+		imageResult, err := utils.SaveImage(file, header, "amenities")
+		if err != nil {
+			respondImageSaveError(w, r, err)
+			return
+		}
+		amenity.ImageURL = imageResult.URL
+	}
+
+	// Save to database; Select("*") so an explicit active=false isn't replaced by the column default
+	if err := db.Select("*").Create(&amenity).Error; err != nil {
+		// If database save fails, delete the uploaded image
+		utils.DeleteImage(amenity.ImageURL)
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Invalidate amenities list and navigation caches
+	_ = utils.CacheDelete(r.Context(), amenitiesCacheKey)
+	invalidateNavigationCache(r.Context())
+
+	// Add BASE_URL to response
+	amenity.ImageURL = utils.PrependBaseURL(amenity.ImageURL, config.GetEnv("BASE_URL", ""))
+
+	utils.RespondSuccess(w, http.StatusCreated, amenity, nil)
+}
+
+// UpdateAmenity updates an amenity
+// Supports partial updates - only send fields you want to change
+func UpdateAmenity(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	db := config.GetDB()
+	var amenity models.Amenity
+	if err := db.First(&amenity, "id = ?", id).Error; err != nil {
+		utils.RespondNotFound(w, "Amenity")
+		return
+	}
+
+	// Parse multipart form data (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondBadRequest(w, "Failed to parse form data")
+		return
+	}
+
+	// Track what was updated for response
+	updated := make(map[string]bool)
+
+	// Update text fields if provided
+	if name := r.FormValue("name"); name != "" {
+		amenity.Name = name
+		updated["name"] = true
+	}
+	if _, ok := r.MultipartForm.Value["description"]; ok {
+		amenity.Description = r.FormValue("description")
+		updated["description"] = true
+	}
+	if _, ok := r.MultipartForm.Value["icon"]; ok {
+		amenity.Icon = r.FormValue("icon")
+		updated["icon"] = true
+	}
+
+	if sortOrderStr := r.FormValue("sort_order"); sortOrderStr != "" {
+		sortOrder, err := strconv.Atoi(sortOrderStr)
+		if err != nil || sortOrder < 0 {
+			utils.RespondValidationError(w, map[string]string{
+				"sort_order": "Sort order must be a non-negative number",
+			})
+			return
+		}
+		amenity.SortOrder = sortOrder
+		updated["sort_order"] = true
+	}
+
+	if activeStr := r.FormValue("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			utils.RespondValidationError(w, map[string]string{
+				"active": "Active must be true or false",
+			})
+			return
+		}
+		amenity.Active = active
+		updated["active"] = true
+	}
+
+	// Handle image operations. The replaced image is only deleted once the
+	// database save succeeds, and a new image is removed again if it fails.
+	var staleImageURL, newImageURL string
+	if r.FormValue("delete_image") == "true" {
+		staleImageURL = amenity.ImageURL
+		amenity.ImageURL = ""
+		updated["image_deleted"] = true
+	} else if file, header, err := r.FormFile("image"); err == nil {
+		defer file.Close()
+
+		imageResult, err := utils.SaveImage(file, header, "amenities")
+		if err != nil {
+			respondImageSaveError(w, r, err)
+			return
+		}
+
+		staleImageURL = amenity.ImageURL
+		newImageURL = imageResult.URL
+		amenity.ImageURL = imageResult.URL
+		updated["image_updated"] = true
+	}
+
+	// Save to database if any field was updated
+	if len(updated) > 0 {
+		if err := db.Save(&amenity).Error; err != nil {
+			utils.DeleteImage(newImageURL)
+			utils.RespondInternalError(w)
+			return
+		}
+
+		utils.DeleteImage(staleImageURL)
+		_ = utils.CacheDelete(r.Context(), amenitiesCacheKey)
+		invalidateNavigationCache(r.Context())
+	}
+
+	// Add BASE_URL to response
+	amenity.ImageURL = utils.PrependBaseURL(amenity.ImageURL, config.GetEnv("BASE_URL", ""))
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"message":        "Amenity updated successfully",
+		"updated_fields": updated,
+		"data":           amenity,
+	}, nil)
+}
+
+// DeleteAmenity soft deletes an amenity and removes its image
+func DeleteAmenity(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	db := config.GetDB()
+	var amenity models.Amenity
+	if err := db.First(&amenity, "id = ?", id).Error; err != nil {
+		utils.RespondNotFound(w, "Amenity")
+		return
+	}
+
+	if err := db.Delete(&amenity).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	utils.DeleteImage(amenity.ImageURL)
+	_ = utils.CacheDelete(r.Context(), amenitiesCacheKey)
+	invalidateNavigationCache(r.Context())
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"message": "Amenity deleted successfully",
+	}, nil)
+}
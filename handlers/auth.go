@@ -1,14 +1,20 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
-	"os"
+	"strings"
 	"time"
 
 	"sentul-golf-be/config"
 	"sentul-golf-be/models"
 	"sentul-golf-be/utils"
+
+	"gorm.io/gorm"
 )
 
 type RegisterRequest struct {
@@ -29,6 +35,12 @@ type LoginData struct {
 	ExpiresAt int64  `json:"expires_at"` // Unix timestamp
 }
 
+// normalizeEmail trims and lowercases an email so addresses differing only in
+// case belong to the same account
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // Register creates a new user
 func Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
@@ -36,19 +48,24 @@ func Register(w http.ResponseWriter, r *http.Request) {
 		utils.RespondBadRequest(w, "Invalid request payload")
 		return
 	}
+	req.Email = normalizeEmail(req.Email)
 
 	// Validate input
-	if req.Email == "" || req.Password == "" || req.Name == "" {
-		fields := make(map[string]string)
-		if req.Name == "" {
-			fields["name"] = "Name is required"
-		}
-		if req.Email == "" {
-			fields["email"] = "Email is required"
-		}
-		if req.Password == "" {
-			fields["password"] = "Password is required"
-		}
+	fields := make(map[string]string)
+	if req.Name == "" {
+		fields["name"] = "Name is required"
+	}
+	if req.Email == "" {
+		fields["email"] = "Email is required"
+	} else if err := utils.ValidateEmail(req.Email); err != nil {
+		fields["email"] = "Email " + err.Error()
+	}
+	if req.Password == "" {
+		fields["password"] = "Password is required"
+	} else if err := utils.ValidatePassword(req.Password); err != nil {
+		fields["password"] = "Password " + err.Error()
+	}
+	if len(fields) > 0 {
 		utils.RespondValidationError(w, fields)
 		return
 	}
@@ -73,16 +90,24 @@ func Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	db := config.GetDB()
+
+	// Check if email already exists, also matching older mixed-case addresses
+	var existing models.User
+	if err := db.Unscoped().Where("LOWER(email) = ?", req.Email).First(&existing).Error; err == nil {
+		utils.RespondError(w, http.StatusConflict, "EMAIL_EXISTS", "Email already registered", nil)
+		return
+	}
+
+	// The unique index still catches concurrent registrations
 	if err := db.Create(&user).Error; err != nil {
-		// Check if email already exists
-		if err.Error() == "duplicate key value violates unique constraint \"idx_users_email\"" || 
-		   err.Error() == "UNIQUE constraint failed: users.email" {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			utils.RespondError(w, http.StatusConflict, "EMAIL_EXISTS", "Email already registered", nil)
 			return
 		}
 		utils.RespondInternalError(w)
 		return
 	}
+	invalidateDashboardStats(r.Context())
 
 	// Return user data without password
 	userData := map[string]interface{}{
@@ -117,7 +142,7 @@ func Login(w http.ResponseWriter, r *http.Request) {
 
 	db := config.GetDB()
 	var user models.User
-	if err := db.Where("email = ?", req.Email).First(&user).Error; err != nil {
+	if err := db.Where("LOWER(email) = ?", normalizeEmail(req.Email)).First(&user).Error; err != nil {
 		utils.RespondUnauthorized(w, "Invalid credentials")
 		return
 	}
@@ -129,21 +154,227 @@ func Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate JWT token
-	token, err := utils.GenerateJWT(user.ID, user.Email, string(user.Role), os.Getenv("JWT_SECRET"))
+	token, tokenClaims, err := utils.GenerateJWT(user.ID, user.Email, string(user.Role))
 	if err != nil {
 		utils.RespondInternalError(w)
 		return
 	}
 
-	// Calculate expiry time (24 hours from now)
-	expiresAt := time.Now().Add(24 * time.Hour).Unix()
+	// Track the session so admins can review and revoke it
+	_ = utils.TrackSession(r.Context(), tokenClaims, r)
 
 	// Return user_id, token, and expiry
 	loginData := LoginData{
 		UserID:    user.ID,
 		Token:     token,
-		ExpiresAt: expiresAt,
+		ExpiresAt: tokenClaims.ExpiresAt.Unix(),
 	}
 
 	utils.RespondSuccess(w, http.StatusOK, loginData, nil)
 }
+
+// RefreshToken issues a fresh token for a valid token, or one that expired
+// within the refresh grace window, without asking for credentials again.
+// Expects the current token as "Authorization: Bearer <token>".
+func RefreshToken(w http.ResponseWriter, r *http.Request) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		utils.RespondUnauthorized(w, "Authorization header required")
+		return
+	}
+
+	// Extract token from "Bearer <token>"
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		utils.RespondUnauthorized(w, "Invalid authorization header format")
+		return
+	}
+
+	claims, err := utils.ValidateJWTForRefresh(parts[1])
+	if err != nil {
+		utils.RespondUnauthorized(w, "Invalid or expired token")
+		return
+	}
+	if utils.IsTokenRevoked(r.Context(), claims.ID) {
+		utils.RespondUnauthorized(w, "Token has been revoked")
+		return
+	}
+	if claims.ImpersonatedBy != "" {
+		utils.RespondForbidden(w, "Impersonation tokens can't be refreshed")
+		return
+	}
+
+	// Verify user still exists in database (not deleted)
+	db := config.GetDB()
+	var user models.User
+	if err := db.Where("id = ?", claims.UserID).First(&user).Error; err != nil {
+		utils.RespondUnauthorized(w, "User not found or has been deleted")
+		return
+	}
+
+	// Issue a new token with the user's current email and role
+	token, tokenClaims, err := utils.GenerateJWT(user.ID, user.Email, string(user.Role))
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// The old token can't be refreshed again once it has been exchanged,
+	// so its session is replaced by the new one
+	_ = utils.RevokeToken(r.Context(), claims)
+	_ = utils.UntrackSession(r.Context(), claims.UserID, claims.ID)
+	_ = utils.TrackSession(r.Context(), tokenClaims, r)
+
+	utils.RespondSuccess(w, http.StatusOK, LoginData{
+		UserID:    user.ID,
+		Token:     token,
+		ExpiresAt: tokenClaims.ExpiresAt.Unix(),
+	}, nil)
+}
+
+// Logout revokes the current token so it can't be used or refreshed again
+func Logout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := requireClaims(w, r)
+	if !ok {
+		return
+	}
+
+	// Without Redis the token simply stays valid until it expires
+	if err := utils.RevokeToken(r.Context(), claims); err != nil && utils.IsRedisAvailable() {
+		utils.RespondInternalError(w)
+		return
+	}
+	_ = utils.UntrackSession(r.Context(), claims.UserID, claims.ID)
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "Logged out successfully",
+	}, nil)
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// ForgotPassword emails a password reset link. The response is the same
+// whether or not the email belongs to a user, so it can't be used to find
+// registered accounts.
+func ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	// Throttle per client IP since every request may send an email
+	limit := utils.HitRateLimit(r.Context(), "forgot-password:"+utils.ClientIP(r), 5, 15*time.Minute)
+	utils.SetRateLimitHeaders(w, limit)
+	if !limit.Allowed {
+		utils.RespondError(w, http.StatusTooManyRequests, "RATE_LIMITED", "Too many password reset requests. Please try again later", nil)
+		return
+	}
+
+	var req ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondBadRequest(w, "Invalid request payload")
+		return
+	}
+	req.Email = normalizeEmail(req.Email)
+	if req.Email == "" {
+		utils.RespondValidationError(w, map[string]string{
+			"email": "Email is required",
+		})
+		return
+	}
+
+	// Reset tokens live in Redis
+	if !utils.IsRedisAvailable() {
+		utils.RespondError(w, http.StatusServiceUnavailable, "PASSWORD_RESET_UNAVAILABLE", "Password reset is unavailable without Redis", nil)
+		return
+	}
+
+	db := config.GetDB()
+	var user models.User
+	if err := db.Where("LOWER(email) = ?", req.Email).First(&user).Error; err == nil {
+		token, err := utils.CreatePasswordResetToken(r.Context(), user.ID)
+		if err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+
+		// Send in the background so the response time doesn't reveal whether the user exists
+		link := config.GetEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password") + "?token=" + token
+		body := fmt.Sprintf("Hello %s,\n\nUse the link below to choose a new password. It expires in %s.\n\n%s\n\nIf you didn't ask to reset your password, you can ignore this email.\n",
+			user.Name, utils.PasswordResetTTL(), link)
+		go func() {
+			if err := utils.SendMail(context.Background(), user.Email, "Reset your password", body); err != nil {
+				log.Printf("Warning: Failed to send password reset email to user %s: %v", user.ID, err)
+			}
+		}()
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "If the email is registered, a password reset link has been sent",
+	}, nil)
+}
+
+// ResetPassword sets a new password using a token from ForgotPassword and
+// signs the user out everywhere
+func ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondBadRequest(w, "Invalid request payload")
+		return
+	}
+
+	fields := make(map[string]string)
+	if req.Token == "" {
+		fields["token"] = "Token is required"
+	}
+	if err := utils.ValidatePassword(req.NewPassword); err != nil {
+		fields["new_password"] = "New password " + err.Error()
+	}
+	if len(fields) > 0 {
+		utils.RespondValidationError(w, fields)
+		return
+	}
+
+	if !utils.IsRedisAvailable() {
+		utils.RespondError(w, http.StatusServiceUnavailable, "PASSWORD_RESET_UNAVAILABLE", "Password reset is unavailable without Redis", nil)
+		return
+	}
+
+	// The token is used up even if the rest fails; the user can ask for a new one
+	ctx := r.Context()
+	userID, err := utils.ConsumePasswordResetToken(ctx, req.Token)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_TOKEN", "Reset token is invalid or has expired", nil)
+		return
+	}
+
+	db := config.GetDB()
+	var user models.User
+	if err := db.Where("id = ?", userID).First(&user).Error; err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_TOKEN", "Reset token is invalid or has expired", nil)
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	if err := db.Model(&user).Update("password", hashedPassword).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Sign out existing sessions, which may belong to whoever knew the old password
+	if sessions, err := utils.ListSessions(ctx, user.ID); err == nil {
+		for _, session := range sessions {
+			_ = utils.RevokeSession(ctx, user.ID, session.ID)
+		}
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "Password reset successfully",
+	}, nil)
+}
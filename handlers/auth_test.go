@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sentul-golf-be/models"
+
+	"gorm.io/gorm"
+)
+
+func TestRegisterDuplicateEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string // Email of an existing user, "" for none
+		deleted    bool   // The existing user is soft-deleted
+		concurrent bool   // The email is taken between the check and the insert
+		email      string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "new email", email: "new@example.com", wantStatus: http.StatusCreated},
+		{name: "same email", existing: "taken@example.com", email: "taken@example.com", wantStatus: http.StatusConflict, wantCode: "EMAIL_EXISTS"},
+		{name: "different case", existing: "taken@example.com", email: "Taken@Example.COM", wantStatus: http.StatusConflict, wantCode: "EMAIL_EXISTS"},
+		{name: "surrounding spaces", existing: "taken@example.com", email: "  taken@example.com ", wantStatus: http.StatusConflict, wantCode: "EMAIL_EXISTS"},
+		{name: "older mixed-case row", existing: "Taken@Example.com", email: "taken@example.com", wantStatus: http.StatusConflict, wantCode: "EMAIL_EXISTS"},
+		{name: "soft-deleted user", existing: "taken@example.com", deleted: true, email: "taken@example.com", wantStatus: http.StatusConflict, wantCode: "EMAIL_EXISTS"},
+		{name: "concurrent registration", concurrent: true, email: "race@example.com", wantStatus: http.StatusConflict, wantCode: "EMAIL_EXISTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			if tt.existing != "" {
+				user := createTestUser(t, db, tt.existing, models.RoleUser)
+				if tt.deleted {
+					db.Delete(&user)
+				}
+			}
+			if tt.concurrent {
+				db.Callback().Create().Before("gorm:create").Register("test:take_email", func(tx *gorm.DB) {
+					if tx.Statement.Table == "users" {
+						email := tx.Statement.ReflectValue.FieldByName("Email").String()
+						tx.Session(&gorm.Session{NewDB: true}).Exec(
+							"INSERT INTO users (id, name, email, password, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+							"racer", "Racer", email, "hashed", models.RoleUser, time.Now(), time.Now(),
+						)
+					}
+				})
+			}
+
+			r := newJSONRequest(t, http.MethodPost, "/api/auth/register", map[string]string{
+				"name":     "New User",
+				"email":    tt.email,
+				"password": "golf2025",
+			})
+			w := httptest.NewRecorder()
+			Register(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				if code := errorCode(t, w); code != tt.wantCode {
+					t.Errorf("error code = %q, want %q", code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
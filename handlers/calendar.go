@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+)
+
+// icsTimeFormat is an iCalendar UTC date-time, e.g. 20250102T150405Z
+const icsTimeFormat = "20060102T150405Z"
+
+// icsUIDDomain makes event UIDs globally unique while staying stable across
+// deployments, so re-subscribing never duplicates entries
+const icsUIDDomain = "sentul-golf-be"
+
+// icsTextEscaper escapes the characters iCalendar TEXT values reserve
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// writeICSLine writes a content line, folded to 75 octets as RFC 5545
+// requires without splitting a UTF-8 character
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// eventPageURL returns the public page of an event: EVENT_PAGE_URL with the
+// slug appended, else the event's page under SITE_URL, else the API's slug
+// endpoint when the frontend isn't configured
+func eventPageURL(slug string) string {
+	if pageURL := config.GetEnv("EVENT_PAGE_URL", ""); pageURL != "" {
+		return strings.TrimSuffix(pageURL, "/") + "/" + slug
+	}
+	if config.GetEnv("SITE_URL", "") != "" {
+		return siteURL() + "/events/" + slug
+	}
+	return config.GetEnv("BASE_URL", "") + "/api/events/slug/" + slug
+}
+
+// buildEventCalendar renders events as a VCALENDAR with one VEVENT each,
+// all times in UTC
+func buildEventCalendar(events []models.Event) string {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//"+icsUIDDomain+"//Events//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	// Ask subscribed clients to re-fetch hourly
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeICSLine(&b, "X-PUBLISHED-TTL:PT1H")
+	writeICSLine(&b, "X-WR-CALNAME:"+icsTextEscaper.Replace(config.GetEnv("CALENDAR_NAME", "Sentul Golf Events")))
+
+	for _, event := range events {
+		pageURL := eventPageURL(event.Slug)
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, fmt.Sprintf("UID:%s@%s", event.ID, icsUIDDomain))
+		writeICSLine(&b, "DTSTAMP:"+event.UpdatedAt.UTC().Format(icsTimeFormat))
+		writeICSLine(&b, "DTSTART:"+event.EventStart.UTC().Format(icsTimeFormat))
+		if event.EventEnd != nil {
+			writeICSLine(&b, "DTEND:"+event.EventEnd.UTC().Format(icsTimeFormat))
+		}
+		writeICSLine(&b, "SUMMARY:"+icsTextEscaper.Replace(event.Title))
+		writeICSLine(&b, "DESCRIPTION:"+icsTextEscaper.Replace(pageURL))
+		writeICSLine(&b, "URL:"+pageURL)
+		if event.Location != "" {
+			writeICSLine(&b, "LOCATION:"+icsTextEscaper.Replace(event.Location))
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// GetEventCalendar returns the published events that have a start time as an
+// iCalendar feed, so members can subscribe from Google or Apple Calendar
+func GetEventCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Try cache first
+	cacheKey := utils.CacheKeyEventCalendar
+	var calendar string
+	if err := cacheGet(ctx, r, cacheKey, &calendar); err != nil {
+		// Cache miss - load the scheduled events
+		var events []models.Event
+		if err := config.GetDB().
+			Select("id, title, slug, event_start, event_end, location, updated_at").
+			Where("published = ? AND event_start IS NOT NULL", true).
+			Order("event_start ASC, id ASC").
+			Find(&events).Error; err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+
+		calendar = buildEventCalendar(events)
+		_ = utils.CacheSet(ctx, cacheKey, calendar, utils.CacheTTLEventsList)
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="events.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(calendar))
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/middleware"
+	"sentul-golf-be/utils"
+)
+
+// Limits for client error reports
+const (
+	maxClientErrorBody    = 16 << 10 // 16KB
+	maxClientErrorMessage = 1000
+	maxClientErrorStack   = 8000
+	maxClientErrorURL     = 2000
+	maxClientErrorAgent   = 500
+)
+
+// ClientErrorReport is an error the frontend ran into
+type ClientErrorReport struct {
+	Message   string `json:"message"`
+	Stack     string `json:"stack"`
+	URL       string `json:"url"`
+	UserAgent string `json:"user_agent"`
+}
+
+// clientErrorRateLimit returns how many reports a client may send per minute
+func clientErrorRateLimit() int {
+	if l, err := strconv.Atoi(config.GetEnv("CLIENT_ERROR_RATE_LIMIT", "")); err == nil && l > 0 {
+		return l
+	}
+	return 10
+}
+
+// ReportClientError logs an error report from the frontend together with the
+// user (if authenticated) and a request ID the frontend can show or log
+func ReportClientError(w http.ResponseWriter, r *http.Request) {
+	// Throttle per client IP
+	limit := utils.HitRateLimit(r.Context(), "client-errors:"+utils.ClientIP(r), clientErrorRateLimit(), time.Minute)
+	utils.SetRateLimitHeaders(w, limit)
+	if !limit.Allowed {
+		utils.RespondError(w, http.StatusTooManyRequests, "RATE_LIMITED", "Too many error reports. Please try again later", nil)
+		return
+	}
+
+	// Cap the payload size
+	r.Body = http.MaxBytesReader(w, r.Body, maxClientErrorBody)
+	var report ClientErrorReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		utils.RespondBadRequest(w, "Invalid request payload")
+		return
+	}
+
+	report.Message = strings.TrimSpace(report.Message)
+	if report.Message == "" {
+		utils.RespondValidationError(w, map[string]string{
+			"message": "Message is required",
+		})
+		return
+	}
+
+	// Keep log lines bounded
+	report.Message = truncate(report.Message, maxClientErrorMessage)
+	report.Stack = truncate(report.Stack, maxClientErrorStack)
+	report.URL = truncate(report.URL, maxClientErrorURL)
+	if report.UserAgent == "" {
+		report.UserAgent = r.UserAgent()
+	}
+	report.UserAgent = truncate(report.UserAgent, maxClientErrorAgent)
+
+	// Reuse the frontend's request ID when it sent one
+	id := requestID(r)
+
+	userID := "anonymous"
+	if claims, ok := r.Context().Value(middleware.UserContextKey).(*utils.Claims); ok {
+		userID = claims.UserID
+	}
+
+	log.Printf("CLIENT_ERROR: request_id=%s user=%s ip=%s url=%q user_agent=%q message=%q stack=%q",
+		id, userID, utils.ClientIP(r), report.URL, report.UserAgent, report.Message, report.Stack)
+
+	utils.RespondSuccess(w, http.StatusAccepted, map[string]string{
+		"request_id": id,
+	}, nil)
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
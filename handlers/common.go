@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/middleware"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// errCacheBypassed is returned by cacheGet when the cache was deliberately skipped
+var errCacheBypassed = errors.New("cache bypassed")
+
+// errSlugTaken is returned from a create transaction when the unique slug
+// index rejects the row, i.e. another request took the slug since the check
+var errSlugTaken = errors.New("slug already exists")
+
+// maxCreateAttempts bounds how often a create with a generated slug is
+// retried with the next free slug after losing it to a concurrent create
+const maxCreateAttempts = 3
+
+// respondSlugTaken answers a create whose chosen slug was taken concurrently
+func respondSlugTaken(w http.ResponseWriter) {
+	utils.RespondError(w, http.StatusConflict, "SLUG_EXISTS", "Slug already exists. Please use a different slug.", nil)
+}
+
+// isAdmin reports whether the request was made by an authenticated admin
+func isAdmin(r *http.Request) bool {
+	claims, ok := r.Context().Value(middleware.UserContextKey).(*utils.Claims)
+	return ok && claims.Role == string(models.RoleAdmin)
+}
+
+// requireClaims returns the claims AuthMiddleware put on the request. A
+// protected route without them is missing the middleware, which is a server
+// bug rather than an authentication failure, so it answers 500 instead of 401.
+func requireClaims(w http.ResponseWriter, r *http.Request) (*utils.Claims, bool) {
+	claims, ok := r.Context().Value(middleware.UserContextKey).(*utils.Claims)
+	if !ok {
+		log.Printf("No user claims on %s %s; is the route behind AuthMiddleware?", r.Method, r.URL.Path)
+		utils.RespondInternalError(w)
+	}
+	return claims, ok
+}
+
+// requestID returns the request ID the client sent in X-Request-ID, or a new
+// one so log lines and error responses can still be matched up
+func requestID(r *http.Request) string {
+	if id := truncate(strings.TrimSpace(r.Header.Get("X-Request-ID")), 64); id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// respondImageSaveError answers a failed utils.SaveImage. Only a rejected
+// file is the client's fault (400); a full uploads disk (507) and a failing
+// storage backend (500) are logged with the request ID for ops.
+func respondImageSaveError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, utils.ErrStorageFull):
+		id := requestID(r)
+		log.Printf("STORAGE_FULL: request_id=%s %s %s: uploads storage has no space left", id, r.Method, r.URL.Path)
+		utils.RespondError(w, http.StatusInsufficientStorage, "STORAGE_FULL", "The server is out of storage space. Please try again later", map[string]string{
+			"request_id": id,
+		})
+	case errors.Is(err, utils.ErrStorageFailed):
+		id := requestID(r)
+		log.Printf("STORAGE_ERROR: request_id=%s %s %s: %v", id, r.Method, r.URL.Path, err)
+		utils.RespondError(w, http.StatusInternalServerError, "STORAGE_ERROR", "The image could not be stored. Please try again later", map[string]string{
+			"request_id": id,
+		})
+	default:
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+	}
+}
+
+// cacheGet reads a cached value unless an admin asked for a fresh read with
+// ?no_cache=true. The parameter is ignored for everyone else so anonymous
+// clients can't use it to bust the cache.
+func cacheGet(ctx context.Context, r *http.Request, key string, dest interface{}) error {
+	if r.URL.Query().Get("no_cache") == "true" && isAdmin(r) {
+		return errCacheBypassed
+	}
+	return utils.CacheGet(ctx, key, dest)
+}
+
+// preloadAuthor preloads the author relation. Admins also get soft-deleted
+// authors so bylines don't go blank in the admin UI after a user is removed.
+func preloadAuthor(db *gorm.DB, r *http.Request) *gorm.DB {
+	if isAdmin(r) {
+		return db.Preload("Author", func(tx *gorm.DB) *gorm.DB {
+			return tx.Unscoped()
+		})
+	}
+	return db.Preload("Author")
+}
+
+// simplifyAuthor builds the byline for a content item. A missing author
+// (e.g. soft-deleted, for public views) falls back to DEFAULT_AUTHOR_NAME.
+func simplifyAuthor(author models.User) SimplifiedAuthor {
+	if author.ID == "" {
+		return SimplifiedAuthor{Name: config.GetEnv("DEFAULT_AUTHOR_NAME", "Sentul Golf")}
+	}
+	return SimplifiedAuthor{
+		ID:      author.ID,
+		Name:    author.Name,
+		Deleted: author.DeletedAt.Valid,
+	}
+}
+
+// formValue returns a form value and whether the field was sent at all,
+// so an explicitly empty field can be told apart from a missing one
+func formValue(r *http.Request, key string) (string, bool) {
+	values, ok := r.Form[key]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// maxSearchLength caps the search query parameter, in bytes
+const maxSearchLength = 100
+
+// searchParam returns the normalized "search" query parameter
+func searchParam(r *http.Request) string {
+	return normalizeSearch(r.URL.Query().Get("search"))
+}
+
+// normalizeSearch lowercases, trims and caps a search query
+func normalizeSearch(search string) string {
+	search = strings.ToLower(strings.TrimSpace(search))
+	return truncate(search, maxSearchLength)
+}
+
+// searchCondition is the case-insensitive title/excerpt match used by list searches
+const searchCondition = `(LOWER(title) LIKE ? ESCAPE '\' OR LOWER(excerpt) LIKE ? ESCAPE '\')`
+
+// applySearch filters query to rows whose title or excerpt contains search
+func applySearch(query *gorm.DB, search string) *gorm.DB {
+	if search == "" {
+		return query
+	}
+	pattern := utils.ContainsPattern(search)
+	return query.Where(searchCondition, pattern, pattern)
+}
+
+// listOrder returns the ORDER BY clause for the news and events lists.
+// Featured rows always come first; the date direction comes from
+// LIST_SORT_DIRECTION (desc by default) and the id is added as a tie-breaker
+// so rows sharing a timestamp page consistently.
+func listOrder() string {
+	direction := "DESC"
+	if strings.EqualFold(config.GetEnv("LIST_SORT_DIRECTION", ""), "asc") {
+		direction = "ASC"
+	}
+	return "featured DESC, created_at " + direction + ", id " + direction
+}
+
+// featuredOnly reports whether a list asked for pinned items only with ?featured=true
+func featuredOnly(r *http.Request) bool {
+	return r.URL.Query().Get("featured") == "true"
+}
+
+// applyFeatured restricts query to featured rows when only is set
+func applyFeatured(query *gorm.DB, only bool) *gorm.DB {
+	if !only {
+		return query
+	}
+	return query.Where("featured = ?", true)
+}
+
+// addPublishedBreakdown adds the published/draft split of meta.Total to an
+// admin list's meta. countQuery is the list's filtered count query and must
+// be a reusable session.
+func addPublishedBreakdown(meta *utils.Meta, countQuery *gorm.DB) {
+	var published int64
+	if err := countQuery.Where("published = ?", true).Count(&published).Error; err != nil {
+		return
+	}
+
+	publishedCount := int(published)
+	draftCount := meta.Total - publishedCount
+	meta.PublishedCount = &publishedCount
+	meta.DraftCount = &draftCount
+}
+
+// parsePublishAt parses the publish_at form field (RFC3339 or YYYY-MM-DD).
+// An empty value means no schedule. Writes an INVALID_DATE error and returns
+// false when the value is malformed.
+func parsePublishAt(w http.ResponseWriter, value string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+	parsed, err := utils.ParseDate(value)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid publish_at format. Use RFC3339 (2006-01-02T15:04:05Z07:00) or YYYY-MM-DD", nil)
+		return nil, false
+	}
+	return &parsed, true
+}
+
+// publishedOnlyFor hides unpublished rows from everyone but admins
+func publishedOnlyFor(query *gorm.DB, r *http.Request) *gorm.DB {
+	if isAdmin(r) {
+		return query
+	}
+	return query.Where("published = ?", true)
+}
+
+// fieldLengthLimits maps length-limited text fields to their environment
+// variable and default maximum length in characters
+var fieldLengthLimits = map[string]struct {
+	env string
+	max int
+}{
+	"title":   {"MAX_TITLE_LENGTH", 200},
+	"slug":    {"MAX_SLUG_LENGTH", 200},
+	"content": {"MAX_CONTENT_LENGTH", 200000},
+}
+
+// fieldLengthError returns a validation message when value is longer than the
+// configured maximum for field, or "" when it fits. Content is checked after
+// sanitization since that is what gets stored.
+func fieldLengthError(field, value string) string {
+	limit := fieldLengthLimits[field]
+	max := limit.max
+	if v, err := strconv.Atoi(config.GetEnv(limit.env, "")); err == nil && v > 0 {
+		max = v
+	}
+	if utf8.RuneCountInString(value) <= max {
+		return ""
+	}
+	return fmt.Sprintf("%s must be at most %d characters", strings.ToUpper(field[:1])+field[1:], max)
+}
+
+// excerptLengthError returns a validation message when a manual excerpt
+// would not fit the excerpt column, or "" when it does
+func excerptLengthError(excerpt string) string {
+	if utf8.RuneCountInString(excerpt) <= utils.MaxExcerptLength {
+		return ""
+	}
+	return fmt.Sprintf("Excerpt must be at most %d characters", utils.MaxExcerptLength)
+}
+
+// canonicalURLError validates an optional canonical URL, returning "" when
+// it is empty or a valid http(s) URL
+func canonicalURLError(canonicalURL string) string {
+	if canonicalURL == "" {
+		return ""
+	}
+	if err := utils.ValidateHTTPURL(canonicalURL); err != nil {
+		return "Canonical URL " + err.Error()
+	}
+	return ""
+}
+
+// audit records an administrative action by the authenticated user
+func audit(r *http.Request, action, targetType, targetID, details string) {
+	entry := models.AuditLog{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+	}
+	if claims, ok := r.Context().Value(middleware.UserContextKey).(*utils.Claims); ok {
+		entry.ActorID = claims.UserID
+	}
+	utils.RecordAudit(entry)
+}
+
+// updatedFields lists the fields an update changed, for audit details
+func updatedFields(updated map[string]bool) string {
+	fields := make([]string, 0, len(updated))
+	for field := range updated {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ",")
+}
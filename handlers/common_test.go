@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"sentul-golf-be/utils"
+)
+
+func TestExcerptLengthError(t *testing.T) {
+	tests := []struct {
+		name    string
+		excerpt string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"ascii at the limit", strings.Repeat("a", 200), false},
+		{"ascii over the limit", strings.Repeat("a", 201), true},
+		{"accented at the limit", strings.Repeat("é", 200), false}, // 400 bytes
+		{"cjk at the limit", strings.Repeat("高", 200), false},      // 600 bytes
+		{"cjk over the limit", strings.Repeat("高", 201), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := excerptLengthError(tt.excerpt)
+			if (msg != "") != tt.wantErr {
+				t.Fatalf("excerptLengthError() = %q, want error %v", msg, tt.wantErr)
+			}
+			if tt.wantErr && msg != "Excerpt must be at most 200 characters" {
+				t.Errorf("excerptLengthError() = %q", msg)
+			}
+		})
+	}
+}
+
+func TestSearchParam(t *testing.T) {
+	tests := []struct {
+		name   string
+		search string
+		want   string
+	}{
+		{"empty", "", ""},
+		{"trimmed and lowercased", "  Sentul OPEN ", "sentul open"},
+		{"ascii over the limit", strings.Repeat("a", 150), strings.Repeat("a", 100)},
+		{"multibyte at the cut", strings.Repeat("a", 99) + "é", strings.Repeat("a", 99)},
+		{"cjk over the limit", strings.Repeat("高", 40), strings.Repeat("高", 33)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/news?search="+url.QueryEscape(tt.search), nil)
+			got := searchParam(r)
+			if got != tt.want {
+				t.Errorf("searchParam() = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("searchParam() = %q is not valid UTF-8", got)
+			}
+		})
+	}
+}
+
+func TestRespondImageSaveError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"invalid file", errors.New("file extension .gif is not allowed"), http.StatusBadRequest, "INVALID_IMAGE"},
+		{"disk full", utils.ErrStorageFull, http.StatusInsufficientStorage, "STORAGE_FULL"},
+		{"storage backend failed", fmt.Errorf("%w: put object: connection refused", utils.ErrStorageFailed), http.StatusInternalServerError, "STORAGE_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			respondImageSaveError(w, httptest.NewRequest(http.MethodPost, "/api/upload", nil), tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body utils.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", body.Error.Code, tt.wantCode)
+			}
+			if tt.wantStatus >= 500 && strings.Contains(body.Error.Message, "connection refused") {
+				t.Errorf("message %q leaks the storage error", body.Error.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"sentul-golf-be/utils"
+)
+
+// SanitizePreviewRequest represents the request body for previewing sanitization
+type SanitizePreviewRequest struct {
+	Content string `json:"content"`
+}
+
+// SanitizePreviewResponse shows the input next to what SanitizeHTML keeps
+type SanitizePreviewResponse struct {
+	Input   string             `json:"input"`
+	Output  string             `json:"output"`
+	Removed []utils.RemovedTag `json:"removed"`
+	Changed bool               `json:"changed"`
+}
+
+// SanitizePreview runs content through SanitizeHTML without saving anything,
+// so editors can see which formatting survives before publishing
+func SanitizePreview(w http.ResponseWriter, r *http.Request) {
+	var req SanitizePreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondBadRequest(w, "Invalid request payload")
+		return
+	}
+
+	if req.Content == "" {
+		utils.RespondValidationError(w, map[string]string{
+			"content": "Content is required",
+		})
+		return
+	}
+
+	output := utils.SanitizeHTML(req.Content)
+
+	utils.RespondSuccess(w, http.StatusOK, SanitizePreviewResponse{
+		Input:   req.Content,
+		Output:  output,
+		Removed: utils.DiffRemovedTags(req.Content, output),
+		Changed: output != req.Content,
+	}, nil)
+}
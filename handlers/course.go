@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+)
+
+// NineSummary holds par and distance totals for one nine of the course
+type NineSummary struct {
+	Holes    int `json:"holes"`
+	Par      int `json:"par"`
+	Distance int `json:"distance"`
+}
+
+// CourseSummary holds aggregate stats for all playable holes
+type CourseSummary struct {
+	HoleCount     int         `json:"hole_count"`
+	TotalPar      int         `json:"total_par"`
+	TotalDistance int         `json:"total_distance"`
+	FrontNine     NineSummary `json:"front_nine"` // HoleIndex 1-9
+	BackNine      NineSummary `json:"back_nine"`  // HoleIndex 10-18
+}
+
+// ScorecardHole is a single row on the printable scorecard
+type ScorecardHole struct {
+	Number   int    `json:"number"`
+	Name     string `json:"name"`
+	Par      int    `json:"par"`
+	Distance int    `json:"distance"`
+}
+
+// ScorecardResponse is structured for a scorecard layout (out / in / total)
+type ScorecardResponse struct {
+	Holes []ScorecardHole `json:"holes"`
+	Out   NineSummary     `json:"out"`
+	In    NineSummary     `json:"in"`
+	Total NineSummary     `json:"total"`
+}
+
+// buildCourseSummary aggregates par and distance over the given holes
+func buildCourseSummary(holes []models.Hole) CourseSummary {
+	summary := CourseSummary{HoleCount: len(holes)}
+
+	for _, hole := range holes {
+		summary.TotalPar += hole.Par
+		summary.TotalDistance += hole.Distance
+
+		switch {
+		case hole.HoleIndex >= 1 && hole.HoleIndex <= 9:
+			summary.FrontNine.Holes++
+			summary.FrontNine.Par += hole.Par
+			summary.FrontNine.Distance += hole.Distance
+		case hole.HoleIndex >= 10 && hole.HoleIndex <= 18:
+			summary.BackNine.Holes++
+			summary.BackNine.Par += hole.Par
+			summary.BackNine.Distance += hole.Distance
+		}
+	}
+
+	return summary
+}
+
+// GetScorecard returns all playable holes in index order with out/in/total
+// par and distance, ready to be rendered as a printable scorecard
+func GetScorecard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cacheKey := utils.BuildCacheKey("course", "scorecard")
+
+	// Try cache first
+	var response ScorecardResponse
+	if err := cacheGet(ctx, r, cacheKey, &response); err == nil {
+		utils.RespondSuccess(w, http.StatusOK, response, nil)
+		return
+	}
+
+	// Cache miss - get from database
+	db := config.GetDB()
+	var holes []models.Hole
+	if err := db.Order("hole_index ASC").Find(&holes).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	summary := buildCourseSummary(holes)
+
+	response = ScorecardResponse{
+		Holes: make([]ScorecardHole, len(holes)),
+		Out:   summary.FrontNine,
+		In:    summary.BackNine,
+		Total: NineSummary{
+			Holes:    summary.HoleCount,
+			Par:      summary.TotalPar,
+			Distance: summary.TotalDistance,
+		},
+	}
+	for i, hole := range holes {
+		response.Holes[i] = ScorecardHole{
+			Number:   hole.HoleIndex,
+			Name:     hole.Name,
+			Par:      hole.Par,
+			Distance: hole.Distance,
+		}
+	}
+
+	// Cache the response
+	_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLScorecard)
+
+	utils.RespondSuccess(w, http.StatusOK, response, nil)
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sentul-golf-be/models"
+
+	"gorm.io/gorm"
+)
+
+// createHandlers are the content create handlers sharing the upload cleanup
+var createHandlers = []struct {
+	name     string
+	handler  http.HandlerFunc
+	target   string
+	existing func(db *gorm.DB, authorID string) error // Inserts a row with slug "taken"
+}{
+	{
+		name:    "news",
+		handler: CreateNews,
+		target:  "/api/news",
+		existing: func(db *gorm.DB, authorID string) error {
+			return db.Create(&models.News{Title: "Taken", Content: "x", Slug: "taken", AuthorID: authorID}).Error
+		},
+	},
+	{
+		name:    "event",
+		handler: CreateEvent,
+		target:  "/api/events",
+		existing: func(db *gorm.DB, authorID string) error {
+			return db.Create(&models.Event{Title: "Taken", Content: "x", Slug: "taken", AuthorID: authorID}).Error
+		},
+	},
+}
+
+func TestCreateCleansUpImageOnFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		fields     map[string]string
+		setup      func(t *testing.T, db *gorm.DB, create func(db *gorm.DB, authorID string) error, authorID string)
+		noClaims   bool
+		wantStatus int
+		wantFiles  int
+	}{
+		{
+			name:       "created",
+			fields:     map[string]string{"title": "Opening Day", "content": "<p>Hello</p>"},
+			wantStatus: http.StatusCreated,
+			wantFiles:  1,
+		},
+		{
+			name:       "missing title",
+			fields:     map[string]string{"content": "<p>Hello</p>"},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid publish_at",
+			fields:     map[string]string{"title": "Opening Day", "content": "<p>Hello</p>", "publish_at": "tomorrow"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "tag without a slug",
+			fields:     map[string]string{"title": "Opening Day", "content": "<p>Hello</p>", "tags": "golf,高尔夫"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "slug taken",
+			fields:     map[string]string{"title": "Opening Day", "content": "<p>Hello</p>", "slug": "taken"},
+			wantStatus: http.StatusUnprocessableEntity,
+			setup: func(t *testing.T, db *gorm.DB, create func(db *gorm.DB, authorID string) error, authorID string) {
+				if err := create(db, authorID); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name:       "database error",
+			fields:     map[string]string{"title": "Opening Day", "content": "<p>Hello</p>", "tags": "golf"},
+			wantStatus: http.StatusInternalServerError,
+			setup: func(t *testing.T, db *gorm.DB, _ func(db *gorm.DB, authorID string) error, _ string) {
+				if err := db.Migrator().DropTable(&models.Tag{}); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name:       "missing claims",
+			fields:     map[string]string{"title": "Opening Day", "content": "<p>Hello</p>"},
+			noClaims:   true,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, content := range createHandlers {
+		for _, tt := range tests {
+			t.Run(content.name+"/"+tt.name, func(t *testing.T) {
+				db := setupTestDB(t)
+				uploads := setupTestUploads(t)
+				admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+				if tt.setup != nil {
+					tt.setup(t, db, content.existing, admin.ID)
+				}
+
+				r := newFormRequest(t, http.MethodPost, content.target, tt.fields, testPNG(t))
+				if !tt.noClaims {
+					r = withClaims(r, admin.ID, models.RoleAdmin)
+				}
+				w := httptest.NewRecorder()
+				content.handler(w, r)
+
+				if w.Code != tt.wantStatus {
+					t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+				}
+				if files := uploadedFiles(t, uploads); len(files) != tt.wantFiles {
+					t.Errorf("uploaded files = %v, want %d", files, tt.wantFiles)
+				}
+			})
+		}
+	}
+}
+
+func TestCreateEventCleansUpImageOnInvalidDates(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]string
+	}{
+		{"invalid event_start", map[string]string{"event_start": "next friday"}},
+		{"invalid event_end", map[string]string{"event_start": "2026-05-01", "event_end": "05/02/2026"}},
+		{"invalid coordinates", map[string]string{"latitude": "91", "longitude": "0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			uploads := setupTestUploads(t)
+			admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+
+			fields := map[string]string{"title": "Club Night", "content": "<p>Hello</p>"}
+			for key, value := range tt.fields {
+				fields[key] = value
+			}
+			r := withClaims(newFormRequest(t, http.MethodPost, "/api/events", fields, testPNG(t)), admin.ID, models.RoleAdmin)
+			w := httptest.NewRecorder()
+			CreateEvent(w, r)
+
+			if w.Code < 400 || w.Code >= 500 {
+				t.Fatalf("status = %d, want a client error (body %s)", w.Code, w.Body.String())
+			}
+			if files := uploadedFiles(t, uploads); len(files) != 0 {
+				t.Errorf("uploaded files = %v, want none", files)
+			}
+		})
+	}
+}
+
+// stealSlugOnCreate makes the next inserts into table fail on the unique slug
+// index, as if a concurrent request had just taken the slug
+func stealSlugOnCreate(t *testing.T, db *gorm.DB, table string, times int) *int {
+	t.Helper()
+
+	attempts := 0
+	err := db.Callback().Create().Before("gorm:create").Register("test:steal_slug", func(tx *gorm.DB) {
+		if tx.Statement.Table != table {
+			return
+		}
+		attempts++
+		if attempts > times {
+			return
+		}
+		slug := tx.Statement.ReflectValue.FieldByName("Slug").String()
+		tx.Session(&gorm.Session{NewDB: true}).Exec(
+			"INSERT INTO "+table+" (id, title, content, slug, author_id) VALUES (?, ?, ?, ?, ?)",
+			fmt.Sprintf("stolen%d", attempts), "Stolen", "x", slug, "someone",
+		)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &attempts
+}
+
+func TestCreateSlugTakenConcurrently(t *testing.T) {
+	tests := []struct {
+		name         string
+		slug         string // "" for a generated slug
+		steals       int
+		wantStatus   int
+		wantAttempts int
+	}{
+		{"generated slug is retried", "", 1, http.StatusCreated, 2},
+		{"generated slug gives up", "", maxCreateAttempts, http.StatusConflict, maxCreateAttempts},
+		{"chosen slug conflicts", "opening-day", 1, http.StatusConflict, 1},
+	}
+
+	for _, content := range createHandlers {
+		for _, tt := range tests {
+			t.Run(content.name+"/"+tt.name, func(t *testing.T) {
+				db := setupTestDB(t)
+				uploads := setupTestUploads(t)
+				admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+				table := map[string]string{"news": "news", "event": "events"}[content.name]
+				attempts := stealSlugOnCreate(t, db, table, tt.steals)
+
+				fields := map[string]string{"title": "Opening Day", "content": "<p>Hello</p>"}
+				if tt.slug != "" {
+					fields["slug"] = tt.slug
+				}
+				r := withClaims(newFormRequest(t, http.MethodPost, content.target, fields, testPNG(t)), admin.ID, models.RoleAdmin)
+				w := httptest.NewRecorder()
+				content.handler(w, r)
+
+				if w.Code != tt.wantStatus {
+					t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+				}
+				if *attempts != tt.wantAttempts {
+					t.Errorf("insert attempts = %d, want %d", *attempts, tt.wantAttempts)
+				}
+				wantFiles := 0
+				if tt.wantStatus == http.StatusCreated {
+					wantFiles = 1
+				}
+				if files := uploadedFiles(t, uploads); len(files) != wantFiles {
+					t.Errorf("uploaded files = %v, want %d", files, wantFiles)
+				}
+			})
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"sentul-golf-be/config"
@@ -11,6 +14,7 @@ import (
 	"sentul-golf-be/utils"
 
 	"github.com/gorilla/mux"
+	"gorm.io/gorm"
 )
 
 // EventResponse with simplified author (for list)
@@ -19,11 +23,16 @@ type EventResponse struct {
 	Title      string           `json:"title"`
 	Slug       string           `json:"slug"`
 	Published  bool             `json:"published"`
+	Featured   bool             `json:"featured"`
 	ImageURL   string           `json:"image_url"`
 	AuthorID   string           `json:"author_id"`
 	Author     SimplifiedAuthor `json:"author"`
 	EventStart *time.Time       `json:"event_start"`
 	EventEnd   *time.Time       `json:"event_end"`
+	Location   string           `json:"location"`
+	Latitude   *float64         `json:"latitude"`
+	Longitude  *float64         `json:"longitude"`
+	Status     string           `json:"status"` // Computed at response time
 	CreatedAt  time.Time        `json:"created_at"`
 	UpdatedAt  time.Time        `json:"updated_at"`
 }
@@ -35,76 +44,226 @@ type EventDetailResponse struct {
 	Content    string           `json:"content"`
 	Slug       string           `json:"slug"`
 	Published  bool             `json:"published"`
+	Featured   bool             `json:"featured"`
 	ImageURL   string           `json:"image_url"`
 	AuthorID   string           `json:"author_id"`
 	Author     SimplifiedAuthor `json:"author"`
 	EventStart *time.Time       `json:"event_start"`
 	EventEnd   *time.Time       `json:"event_end"`
+	Location   string           `json:"location"`
+	Latitude   *float64         `json:"latitude"`
+	Longitude  *float64         `json:"longitude"`
+	Tags       []models.Tag     `json:"tags"`
+	Images     []models.Image   `json:"images"` // Gallery, in order
+	ViewCount  int64            `json:"view_count"`
+	PublishAt  *time.Time       `json:"publish_at,omitempty"`
+	Status     string           `json:"status"` // Computed at response time
 	CreatedAt  time.Time        `json:"created_at"`
 	UpdatedAt  time.Time        `json:"updated_at"`
+
+	// Original URL of syndicated content for rel="canonical"; empty means
+	// the slug-based URL is canonical
+	CanonicalURL string `json:"canonical_url"`
+
+	// When the item last went live; publish_at only holds a pending schedule
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Storage details of the images, admins with ?include_paths=true only
+	StoredFiles []utils.StoredFile `json:"stored_files,omitempty"`
+}
+
+// Event statuses computed from the event dates relative to server time
+const (
+	EventStatusUpcoming = "upcoming"
+	EventStatusOngoing  = "ongoing"
+	EventStatusPast     = "past"
+	EventStatusUndated  = "undated"
+)
+
+// eventStatusConditions maps the allowed ?status= filters to WHERE clauses
+// comparing the event end (or start when there is no end) with the current time
+var eventStatusConditions = map[string]string{
+	EventStatusUpcoming: "COALESCE(event_end, event_start) >= ?",
+	EventStatusPast:     "COALESCE(event_end, event_start) < ?",
+}
+
+// eventStatus reports whether an event is upcoming, ongoing or past at now
+func eventStatus(start, end *time.Time, now time.Time) string {
+	if start == nil && end == nil {
+		return EventStatusUndated
+	}
+
+	// Events without an end are over once they have started
+	last := end
+	if last == nil {
+		last = start
+	}
+	if last.Before(now) {
+		return EventStatusPast
+	}
+	if start != nil && !start.After(now) {
+		return EventStatusOngoing
+	}
+	return EventStatusUpcoming
+}
+
+// eventStatusParam validates the optional ?status= filter
+func eventStatusParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	status := r.URL.Query().Get("status")
+	if status != "" && eventStatusConditions[status] == "" {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_STATUS", "Status must be 'upcoming' or 'past'", nil)
+		return "", false
+	}
+	return status, true
+}
+
+// applyEventStatus restricts query to events with the given status
+func applyEventStatus(query *gorm.DB, status string, now time.Time) *gorm.DB {
+	if status == "" {
+		return query
+	}
+	return query.Where(eventStatusConditions[status], now)
+}
+
+// notEndedCondition matches events that haven't ended at the given time.
+// Undated events never end.
+const notEndedCondition = "(COALESCE(event_end, event_start) IS NULL OR COALESCE(event_end, event_start) >= ?)"
+
+// hidePastEvents reports whether ended events are left out of the listings
+// served to r. Opt-in with HIDE_PAST_EVENTS=true; admins always see them.
+func hidePastEvents(r *http.Request) bool {
+	return config.GetEnv("HIDE_PAST_EVENTS", "false") == "true" && !isAdmin(r)
+}
+
+// applyHidePastEvents restricts query to events that haven't ended when hide is set
+func applyHidePastEvents(query *gorm.DB, hide bool, now time.Time) *gorm.DB {
+	if !hide {
+		return query
+	}
+	return query.Where(notEndedCondition, now)
 }
 
 // GetEvents retrieves all events with pagination
 func GetEvents(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+
+	// Trash view: soft-deleted events (the route is admin-only)
+	if wantsTrash(r) {
+		respondTrash(w, r, "events", config.GetDB().Model(&models.Event{}), "id, title, slug, deleted_at")
+		return
+	}
 	
 	// Get pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-	
-	page := 1
-	limit := 10 // Default 10 items per page
+	page, limit, offset := utils.ParsePagination(r, utils.DefaultPageLimit)
+
+	// Filter by published status if not admin
+	claims, ok := ctx.Value(middleware.UserContextKey).(*utils.Claims)
+	publishedOnly := !ok || claims.Role != string(models.RoleAdmin)
 	
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
+	// Optional keyword search on title and excerpt
+	search := searchParam(r)
+	// Optional tag filter
+	tags, valid := tagFilterParam(w, r)
+	if !valid {
+		return
+	}
+
+	// Optional event_start window (start_after is inclusive, start_before exclusive)
+	var startAfter, startBefore *time.Time
+	if value := r.URL.Query().Get("start_after"); value != "" {
+		parsed, err := utils.ParseDate(value)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid start_after format. Use RFC3339 (2006-01-02T15:04:05Z07:00) or YYYY-MM-DD", nil)
+			return
 		}
+		startAfter = &parsed
 	}
-	
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
+	if value := r.URL.Query().Get("start_before"); value != "" {
+		parsed, err := utils.ParseDate(value)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid start_before format. Use RFC3339 (2006-01-02T15:04:05Z07:00) or YYYY-MM-DD", nil)
+			return
 		}
+		startBefore = &parsed
 	}
-	
-	offset := (page - 1) * limit
 
-	// Filter by published status if not admin
-	claims, ok := ctx.Value(middleware.UserContextKey).(*utils.Claims)
-	publishedOnly := !ok || claims.Role != string(models.RoleAdmin)
-	
+	// Optional upcoming/past filter
+	status, valid := eventStatusParam(w, r)
+	if !valid {
+		return
+	}
+	now := time.Now()
+	hidePast := hidePastEvents(r)
+	// Optional pinned-only filter
+	featured := featuredOnly(r)
+
 	// Try cache first
 	cacheKey := utils.BuildCacheKey("event", "list", "page", page, "limit", limit, "published", publishedOnly)
+	if hidePast {
+		cacheKey = utils.BuildCacheKey(cacheKey, "hide_past")
+	}
+	cacheKey = tags.cacheKey(cacheKey)
+	if featured {
+		cacheKey = utils.BuildCacheKey(cacheKey, "featured")
+	}
+	if startAfter != nil {
+		cacheKey = utils.BuildCacheKey(cacheKey, "start_after", startAfter.UTC().Format(time.RFC3339))
+	}
+	if startBefore != nil {
+		cacheKey = utils.BuildCacheKey(cacheKey, "start_before", startBefore.UTC().Format(time.RFC3339))
+	}
+	if status != "" {
+		cacheKey = utils.BuildCacheKey(cacheKey, "status", status)
+	}
+	if search != "" {
+		cacheKey = utils.BuildCacheKey(cacheKey, "search", search)
+	}
 	type CachedEventResponse struct {
 		EventResponse []EventResponse `json:"events"`
 		Meta          *utils.Meta     `json:"meta"`
 	}
 	var cached CachedEventResponse
-	if err := utils.CacheGet(ctx, cacheKey, &cached); err == nil {
-		utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
-			"events": cached.EventResponse,
-		}, cached.Meta)
+	if err := cacheGet(ctx, r, cacheKey, &cached); err == nil {
+		baseURL := config.GetEnv("BASE_URL", "")
+		for i := range cached.EventResponse {
+			cached.EventResponse[i].ImageURL = utils.PrependBaseURL(cached.EventResponse[i].ImageURL, baseURL)
+			cached.EventResponse[i].Status = eventStatus(cached.EventResponse[i].EventStart, cached.EventResponse[i].EventEnd, now)
+		}
+		utils.RespondList(w, r, "events", cached.EventResponse, cached.Meta)
 		return
 	}
 	
 	// Cache miss - get from database
 	db := config.GetDB()
 	var events []models.Event
-	query := db.Preload("Author")
+	query := preloadAuthor(db, r)
 	if publishedOnly {
 		query = query.Where("published = ?", true)
 	}
+	query = applySearch(query, search)
+	query = applyTagFilter(query, eventTags, tags)
+	query = applyStartWindow(query, startAfter, startBefore)
+	query = applyEventStatus(query, status, now)
+	query = applyHidePastEvents(query, hidePast, now)
+	query = applyFeatured(query, featured)
 	
 	// Count total items
 	var total int64
 	countQuery := db.Model(&models.Event{})
+	countQuery = applySearch(countQuery, search)
+	countQuery = applyTagFilter(countQuery, eventTags, tags)
+	countQuery = applyStartWindow(countQuery, startAfter, startBefore)
+	countQuery = applyEventStatus(countQuery, status, now)
+	countQuery = applyHidePastEvents(countQuery, hidePast, now)
+	countQuery = applyFeatured(countQuery, featured)
 	if !ok || claims.Role != string(models.RoleAdmin) {
 		countQuery = countQuery.Where("published = ?", true)
 	}
+	countQuery = countQuery.Session(&gorm.Session{})
 	countQuery.Count(&total)
 	
 	// Get paginated results
-	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+	if err := query.Order(listOrder()).Limit(limit).Offset(offset).Find(&events).Error; err != nil {
 		utils.RespondInternalError(w)
 		return
 	}
@@ -117,47 +276,81 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 			Title:      e.Title,
 			Slug:       e.Slug,
 			Published:  e.Published,
+			Featured:   e.Featured,
 			ImageURL:   e.ImageURL,
 			AuthorID:   e.AuthorID,
-			Author: SimplifiedAuthor{
-				ID:   e.Author.ID,
-				Name: e.Author.Name,
-			},
+			Author:     simplifyAuthor(e.Author),
 			EventStart: e.EventStart,
 			EventEnd:   e.EventEnd,
+			Location:   e.Location,
+			Latitude:   e.Latitude,
+			Longitude:  e.Longitude,
 			CreatedAt:  e.CreatedAt,
 			UpdatedAt:  e.UpdatedAt,
 		}
 	}
 	
-	// Calculate total pages
-	totalPages := int(total) / limit
-	if int(total)%limit != 0 {
-		totalPages++
+	meta := utils.NewMeta(page, limit, total)
+	if !publishedOnly {
+		addPublishedBreakdown(meta, countQuery)
 	}
 
-	meta := &utils.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      int(total),
-		TotalPages: totalPages,
+	// Cache the response (skip empty search results so misses aren't cached)
+	if search == "" || len(eventsResponse) > 0 {
+		_ = utils.CacheSet(ctx, cacheKey, CachedEventResponse{
+			EventResponse: eventsResponse,
+			Meta:          meta,
+		}, utils.CacheTTLEventsList)
 	}
 
-	// Cache the response
-	_ = utils.CacheSet(ctx, cacheKey, CachedEventResponse{
-		EventResponse: eventsResponse,
-		Meta:          meta,
-	}, utils.CacheTTLEventsList)
-
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
 	for i := range eventsResponse {
 		eventsResponse[i].ImageURL = utils.PrependBaseURL(eventsResponse[i].ImageURL, baseURL)
+		eventsResponse[i].Status = eventStatus(eventsResponse[i].EventStart, eventsResponse[i].EventEnd, now)
 	}
 
-	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
-		"events": eventsResponse,
-	}, meta)
+	utils.RespondList(w, r, "events", eventsResponse, meta)
+}
+
+// parseCoordinates parses an optional latitude/longitude pair. Both must be
+// given together and within range; problems are added to fields.
+func parseCoordinates(latStr, lngStr string, fields map[string]string) (*float64, *float64) {
+	latStr, lngStr = strings.TrimSpace(latStr), strings.TrimSpace(lngStr)
+	if latStr == "" && lngStr == "" {
+		return nil, nil
+	}
+	if latStr == "" || lngStr == "" {
+		fields["coordinates"] = "Latitude and longitude must be provided together"
+		return nil, nil
+	}
+
+	// ParseFloat accepts "NaN", which compares false against both bounds, and "Inf"
+	latitude, err := strconv.ParseFloat(latStr, 64)
+	if err != nil || math.IsNaN(latitude) || math.IsInf(latitude, 0) || latitude < -90 || latitude > 90 {
+		fields["latitude"] = "Latitude must be a number between -90 and 90"
+	}
+	longitude, err := strconv.ParseFloat(lngStr, 64)
+	if err != nil || math.IsNaN(longitude) || math.IsInf(longitude, 0) || longitude < -180 || longitude > 180 {
+		fields["longitude"] = "Longitude must be a number between -180 and 180"
+	}
+	if fields["latitude"] != "" || fields["longitude"] != "" {
+		return nil, nil
+	}
+
+	return &latitude, &longitude
+}
+
+// applyStartWindow restricts query to events starting in [after, before).
+// Events without an event_start never match once either bound is set.
+func applyStartWindow(query *gorm.DB, after, before *time.Time) *gorm.DB {
+	if after != nil {
+		query = query.Where("event_start >= ?", *after)
+	}
+	if before != nil {
+		query = query.Where("event_start < ?", *before)
+	}
+	return query
 }
 
 // GetEventBySlug retrieves a single event by slug
@@ -167,17 +360,25 @@ func GetEventBySlug(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cacheKey := utils.BuildCacheKey("event", "slug", slug)
 
+	// Admins may see soft-deleted authors, so their view is never cached
+	admin := isAdmin(r)
+
 	// Try cache first
 	var response EventDetailResponse
-	if err := utils.CacheGet(ctx, cacheKey, &response); err == nil {
-		utils.RespondSuccess(w, http.StatusOK, response, nil)
+	if !admin && cacheGet(ctx, r, cacheKey, &response) == nil {
+		utils.IncrementViewCount(ctx, "event", response.ID)
+		response.ImageURL = utils.PrependBaseURL(response.ImageURL, config.GetEnv("BASE_URL", ""))
+		prependGalleryBaseURL(response.Images, config.GetEnv("BASE_URL", ""))
+		response.Status = eventStatus(response.EventStart, response.EventEnd, time.Now())
+		utils.SetCacheControl(w, r, utils.CacheTTLEventDetail)
+		utils.RespondSuccessETag(w, r, response, nil)
 		return
 	}
 
 	// Cache miss - get from database
 	db := config.GetDB()
 	var event models.Event
-	if err := db.Preload("Author").Where("slug = ?", slug).First(&event).Error; err != nil {
+	if err := publishedOnlyFor(preloadGallery(preloadAuthor(db, r).Preload("Tags")), r).Where("slug = ?", slug).First(&event).Error; err != nil {
 		utils.RespondNotFound(w, "Event")
 		return
 	}
@@ -189,26 +390,47 @@ func GetEventBySlug(w http.ResponseWriter, r *http.Request) {
 		Content:    event.Content,
 		Slug:       event.Slug,
 		Published:  event.Published,
+		Featured:   event.Featured,
 		ImageURL:   event.ImageURL,
 		AuthorID:   event.AuthorID,
-		Author: SimplifiedAuthor{
-			ID:   event.Author.ID,
-			Name: event.Author.Name,
-		},
+		Author:     simplifyAuthor(event.Author),
 		EventStart: event.EventStart,
 		EventEnd:   event.EventEnd,
+		Location:   event.Location,
+		Latitude:   event.Latitude,
+		Longitude:  event.Longitude,
+		Tags:       event.Tags,
+		Images:     event.Images,
+		ViewCount:  event.ViewCount,
+		PublishAt:  event.PublishAt,
 		CreatedAt:  event.CreatedAt,
 		UpdatedAt:  event.UpdatedAt,
+
+		CanonicalURL: event.CanonicalURL,
+		PublishedAt:  event.PublishedAt,
 	}
 
+	// Count the view
+	utils.IncrementViewCount(ctx, "event", response.ID)
+
 	// Cache the response
-	_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLEventDetail)
+	if !admin {
+		_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLEventDetail)
+	}
+
+	// Admins can ask where the images are stored
+	if includePaths(r) {
+		response.StoredFiles = storedFiles(response.ImageURL, response.Images)
+	}
 
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
 	response.ImageURL = utils.PrependBaseURL(response.ImageURL, baseURL)
+	prependGalleryBaseURL(response.Images, baseURL)
+	response.Status = eventStatus(response.EventStart, response.EventEnd, time.Now())
 
-	utils.RespondSuccess(w, http.StatusOK, response, nil)
+	utils.SetCacheControl(w, r, utils.CacheTTLEventDetail)
+	utils.RespondSuccessETag(w, r, response, nil)
 }
 
 // GetEventByID retrieves a single event by ID
@@ -218,17 +440,25 @@ func GetEventByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cacheKey := utils.BuildCacheKey("event", "id", id)
 
+	// Admins may see soft-deleted authors, so their view is never cached
+	admin := isAdmin(r)
+
 	// Try cache first
 	var response EventDetailResponse
-	if err := utils.CacheGet(ctx, cacheKey, &response); err == nil {
-		utils.RespondSuccess(w, http.StatusOK, response, nil)
+	if !admin && cacheGet(ctx, r, cacheKey, &response) == nil {
+		utils.IncrementViewCount(ctx, "event", response.ID)
+		response.ImageURL = utils.PrependBaseURL(response.ImageURL, config.GetEnv("BASE_URL", ""))
+		prependGalleryBaseURL(response.Images, config.GetEnv("BASE_URL", ""))
+		response.Status = eventStatus(response.EventStart, response.EventEnd, time.Now())
+		utils.SetCacheControl(w, r, utils.CacheTTLEventDetail)
+		utils.RespondSuccessETag(w, r, response, nil)
 		return
 	}
 
 	// Cache miss - get from database
 	db := config.GetDB()
 	var event models.Event
-	if err := db.Preload("Author").Where("id = ?", id).First(&event).Error; err != nil {
+	if err := publishedOnlyFor(preloadGallery(preloadAuthor(db, r).Preload("Tags")), r).Where("id = ?", id).First(&event).Error; err != nil {
 		utils.RespondNotFound(w, "Event")
 		return
 	}
@@ -240,26 +470,47 @@ func GetEventByID(w http.ResponseWriter, r *http.Request) {
 		Content:    event.Content,
 		Slug:       event.Slug,
 		Published:  event.Published,
+		Featured:   event.Featured,
 		ImageURL:   event.ImageURL,
 		AuthorID:   event.AuthorID,
-		Author: SimplifiedAuthor{
-			ID:   event.Author.ID,
-			Name: event.Author.Name,
-		},
+		Author:     simplifyAuthor(event.Author),
 		EventStart: event.EventStart,
 		EventEnd:   event.EventEnd,
+		Location:   event.Location,
+		Latitude:   event.Latitude,
+		Longitude:  event.Longitude,
+		Tags:       event.Tags,
+		Images:     event.Images,
+		ViewCount:  event.ViewCount,
+		PublishAt:  event.PublishAt,
 		CreatedAt:  event.CreatedAt,
 		UpdatedAt:  event.UpdatedAt,
+
+		CanonicalURL: event.CanonicalURL,
+		PublishedAt:  event.PublishedAt,
 	}
 
+	// Count the view
+	utils.IncrementViewCount(ctx, "event", response.ID)
+
 	// Cache the response
-	_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLEventDetail)
+	if !admin {
+		_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLEventDetail)
+	}
+
+	// Admins can ask where the images are stored
+	if includePaths(r) {
+		response.StoredFiles = storedFiles(response.ImageURL, response.Images)
+	}
 
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
 	response.ImageURL = utils.PrependBaseURL(response.ImageURL, baseURL)
+	prependGalleryBaseURL(response.Images, baseURL)
+	response.Status = eventStatus(response.EventStart, response.EventEnd, time.Now())
 
-	utils.RespondSuccess(w, http.StatusOK, response, nil)
+	utils.SetCacheControl(w, r, utils.CacheTTLEventDetail)
+	utils.RespondSuccessETag(w, r, response, nil)
 }
 
 // CreateEvent creates a new event with image upload
@@ -277,6 +528,7 @@ func CreateEvent(w http.ResponseWriter, r *http.Request) {
 	content = utils.SanitizeHTML(content)
 	slug := r.FormValue("slug")
 	published := r.FormValue("published") == "true"
+	featured := r.FormValue("featured") == "true"
 	eventStartStr := r.FormValue("event_start")
 	eventEndStr := r.FormValue("event_end")
 
@@ -284,10 +536,33 @@ func CreateEvent(w http.ResponseWriter, r *http.Request) {
 	fields := make(map[string]string)
 	if title == "" {
 		fields["title"] = "Title is required"
+	} else if msg := fieldLengthError("title", title); msg != "" {
+		fields["title"] = msg
 	}
 	if content == "" {
 		fields["content"] = "Content is required"
+	} else if msg := fieldLengthError("content", content); msg != "" {
+		fields["content"] = msg
+	}
+	if slug != "" && utils.IsIDLike(slug) {
+		fields["slug"] = "Slug must not look like an ID"
+	} else if msg := fieldLengthError("slug", slug); msg != "" {
+		fields["slug"] = msg
+	}
+	excerpt, manualExcerpt := formValue(r, "excerpt")
+	excerpt = utils.CleanExcerpt(excerpt)
+	if msg := excerptLengthError(excerpt); msg != "" {
+		fields["excerpt"] = msg
+	}
+	canonicalURL := strings.TrimSpace(r.FormValue("canonical_url"))
+	if msg := canonicalURLError(canonicalURL); msg != "" {
+		fields["canonical_url"] = msg
 	}
+	location := strings.TrimSpace(r.FormValue("location"))
+	if len(location) > 255 {
+		fields["location"] = "Location must be at most 255 characters"
+	}
+	latitude, longitude := parseCoordinates(r.FormValue("latitude"), r.FormValue("longitude"), fields)
 	
 	if len(fields) > 0 {
 		utils.RespondValidationError(w, fields)
@@ -295,21 +570,45 @@ func CreateEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Auto-generate slug from title if not provided
-	if slug == "" {
+	autoSlug := slug == ""
+	if autoSlug {
 		slug = utils.GenerateSlug(title)
+		// Titles without any Latin letters or digits (e.g. CJK) give no slug
+		if slug == "" {
+			slug = "event"
+		}
+	}
+
+	// Use the editor's excerpt verbatim, otherwise generate one from content
+	manualExcerpt = manualExcerpt && excerpt != ""
+	if !manualExcerpt {
+		excerpt = utils.MakeExcerpt(content, 160)
+	}
+
+	// Optional scheduled publish time, moot when published right away
+	publishAt, ok := parsePublishAt(w, r.FormValue("publish_at"))
+	if !ok {
+		return
+	}
+	var publishedAt *time.Time
+	if published {
+		now := time.Now()
+		publishAt, publishedAt = nil, &now
+	}
+
+	// Tags must each give a slug
+	tagNames, ok := parseTags(w, r.FormValue("tags"))
+	if !ok {
+		return
 	}
 
 	// Parse event start date if provided
 	var eventStart *time.Time
 	if eventStartStr != "" {
-		parsedDate, err := time.Parse(time.RFC3339, eventStartStr)
+		parsedDate, err := utils.ParseDate(eventStartStr)
 		if err != nil {
-			// Try alternative format (date only, will use 00:00:00)
-			parsedDate, err = time.Parse("2006-01-02", eventStartStr)
-			if err != nil {
-				utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid event_start format. Use RFC3339 (2006-01-02T15:04:05Z07:00) or YYYY-MM-DD", nil)
-				return
-			}
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid event_start format. Use RFC3339 (2006-01-02T15:04:05Z07:00) or YYYY-MM-DD", nil)
+			return
 		}
 		eventStart = &parsedDate
 	}
@@ -317,20 +616,29 @@ func CreateEvent(w http.ResponseWriter, r *http.Request) {
 	// Parse event end date if provided
 	var eventEnd *time.Time
 	if eventEndStr != "" {
-		parsedDate, err := time.Parse(time.RFC3339, eventEndStr)
+		parsedDate, err := utils.ParseDate(eventEndStr)
 		if err != nil {
-			// Try alternative format (date only, will use 00:00:00)
-			parsedDate, err = time.Parse("2006-01-02", eventEndStr)
-			if err != nil {
-				utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid event_end format. Use RFC3339 (2006-01-02T15:04:05Z07:00) or YYYY-MM-DD", nil)
-				return
-			}
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid event_end format. Use RFC3339 (2006-01-02T15:04:05Z07:00) or YYYY-MM-DD", nil)
+			return
 		}
 		eventEnd = &parsedDate
 	}
 
+	// Get author ID from token before anything is stored
+	claims, ok := requireClaims(w, r)
+	if !ok {
+		return
+	}
+
 	// Get the image file (optional)
 	var imageURL string
+	// Remove the uploaded image again on every path that doesn't create the event
+	created := false
+	defer func() {
+		if !created {
+			utils.DeleteImage(imageURL)
+		}
+	}()
 	file, header, err := r.FormFile("image")
 	if err == nil {
 		defer file.Close()
@@ -338,23 +646,35 @@ func CreateEvent(w http.ResponseWriter, r *http.Request) {
 		// Validate and save the image
 		imageResult, err := utils.SaveImage(file, header, "events")
 		if err != nil {
-			utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+			respondImageSaveError(w, r, err)
 			return
 		}
 		imageURL = imageResult.URL
 	}
 
-	// Get author ID from token
-	claims, _ := r.Context().Value(middleware.UserContextKey).(*utils.Claims)
+	// Publishing (now or scheduled) may require an image
+	if (published || publishAt != nil) && imageURL == "" && utils.RequireImageToPublish("event") {
+		utils.RespondValidationError(w, map[string]string{
+			"image": "An image is required to publish",
+		})
+		return
+	}
 
-	// Check if slug already exists
+	// A generated slug gets a "-N" suffix when taken; a slug the editor chose
+	// must be free, including among soft-deleted rows that still hold the
+	// unique index
 	db := config.GetDB()
 	var existingEvent models.Event
-	if err := db.Where("slug = ?", slug).First(&existingEvent).Error; err == nil {
-		// Slug already exists
-		if imageURL != "" {
-			utils.DeleteImage(imageURL) // Clean up uploaded image
+	baseSlug := slug
+	if autoSlug {
+		unique, err := utils.GenerateUniqueSlug(db, "events", slug)
+		if err != nil {
+			utils.RespondInternalError(w)
+			return
 		}
+		slug = unique
+	} else if err := db.Unscoped().Where("slug = ?", slug).First(&existingEvent).Error; err == nil {
+		// Slug already exists
 		utils.RespondValidationError(w, map[string]string{
 			"slug": "Slug already exists. Please use a different slug.",
 		})
@@ -363,33 +683,74 @@ func CreateEvent(w http.ResponseWriter, r *http.Request) {
 
 	// Create event object
 	event := models.Event{
-		Title:      title,
-		Content:    content,
-		Excerpt:    utils.MakeExcerpt(content, 160),
-		Slug:       slug,
-		Published:  published,
-		ImageURL:   imageURL,
-		AuthorID:   claims.UserID,
-		EventStart: eventStart,
-		EventEnd:   eventEnd,
-	}
-
-	// Save to database
-	if err := db.Create(&event).Error; err != nil {
-		// If database save fails, delete the uploaded image
-		if imageURL != "" {
-			utils.DeleteImage(imageURL)
+		Title:         title,
+		Content:       content,
+		Excerpt:       excerpt,
+		ExcerptManual: manualExcerpt,
+		Slug:          slug,
+		Published:     published,
+		Featured:      featured,
+		PublishAt:     publishAt,
+		PublishedAt:   publishedAt,
+		ImageURL:      imageURL,
+		CanonicalURL:  canonicalURL,
+		AuthorID:      claims.UserID,
+		EventStart:    eventStart,
+		EventEnd:      eventEnd,
+		Location:      location,
+		Latitude:      latitude,
+		Longitude:     longitude,
+	}
+
+	// Save to database together with its tags. A concurrent create can take
+	// the slug after the check above: a generated slug then moves on to the
+	// next free one, a slug the editor chose is a conflict.
+	for attempt := 1; ; attempt++ {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			tags, err := upsertTags(tx, tagNames)
+			if err != nil {
+				return err
+			}
+			event.Tags = tags
+			err = tx.Create(&event).Error
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errSlugTaken
+			}
+			return err
+		})
+		if !errors.Is(err, errSlugTaken) || !autoSlug || attempt == maxCreateAttempts {
+			break
 		}
+		if event.Slug, err = utils.GenerateUniqueSlug(db, "events", baseSlug); err != nil {
+			break
+		}
+	}
+	if errors.Is(err, errSlugTaken) {
+		respondSlugTaken(w)
+		return
+	}
+	if err != nil {
 		utils.RespondInternalError(w)
 		return
 	}
+	created = true
 
-	// Invalidate all event list caches
+	audit(r, "event.create", "event", event.ID, "slug="+event.Slug)
+	if event.PublishAt != nil {
+		utils.WakeScheduledPublisher()
+	}
+	if event.Published {
+		utils.NotifyPublished("event", event.ID, event.Slug, event.Title)
+	}
+
+	// Invalidate all event list caches (and the unified posts feed)
 	ctx := r.Context()
-	_ = utils.CacheDeletePattern(ctx, "event:list:*")
+	utils.InvalidateContentCaches(ctx, "event", nil, nil)
 
 	utils.RespondSuccess(w, http.StatusCreated, map[string]interface{}{
-		"id": event.ID,
+		"id":        event.ID,
+		"slug":      event.Slug,
+		"published": event.Published,
 	}, nil)
 }
 
@@ -402,7 +763,7 @@ func UpdateEvent(w http.ResponseWriter, r *http.Request) {
 
 	db := config.GetDB()
 	var event models.Event
-	if err := db.Preload("Author").First(&event, "id = ?", id).Error; err != nil {
+	if err := preloadAuthor(db, r).First(&event, "id = ?", id).Error; err != nil {
 		utils.RespondNotFound(w, "Event")
 		return
 	}
@@ -415,25 +776,79 @@ func UpdateEvent(w http.ResponseWriter, r *http.Request) {
 
 	// Track what was updated for response
 	updated := make(map[string]bool)
+	// Keep the old content for orphan image cleanup after the save
+	oldContent := event.Content
+	// Keep the old slug and state for slug history and cache invalidation
+	oldSlug := event.Slug
+	wasPublished := event.Published
 
 	// Update text fields if provided (only fields that are sent)
 	if title := r.FormValue("title"); title != "" {
+		if msg := fieldLengthError("title", title); msg != "" {
+			utils.RespondValidationError(w, map[string]string{
+				"title": msg,
+			})
+			return
+		}
 		event.Title = title
 		updated["title"] = true
 	}
 	if content := r.FormValue("content"); content != "" {
-		// Save old content before overwriting (for orphan image cleanup)
-		oldContent := event.Content
 		// Sanitize HTML content to prevent XSS
 		event.Content = utils.SanitizeHTML(content)
-		// Regenerate excerpt from sanitized content
-		event.Excerpt = utils.MakeExcerpt(event.Content, 160)
+		if msg := fieldLengthError("content", event.Content); msg != "" {
+			utils.RespondValidationError(w, map[string]string{
+				"content": msg,
+			})
+			return
+		}
 		updated["content"] = true
+		// Regenerate excerpt from sanitized content unless an editor wrote it
+		if !event.ExcerptManual {
+			event.Excerpt = utils.MakeExcerpt(event.Content, 160)
+			updated["excerpt"] = true
+		}
+	}
+	if excerpt, ok := formValue(r, "excerpt"); ok {
+		excerpt = utils.CleanExcerpt(excerpt)
+		if msg := excerptLengthError(excerpt); msg != "" {
+			utils.RespondValidationError(w, map[string]string{"excerpt": msg})
+			return
+		}
+		// An empty excerpt switches back to the auto-generated one
+		event.ExcerptManual = excerpt != ""
+		if event.ExcerptManual {
+			event.Excerpt = excerpt
+		} else {
+			event.Excerpt = utils.MakeExcerpt(event.Content, 160)
+		}
 		updated["excerpt"] = true
-		// Delete inline images that were removed from the content
-		utils.DeleteOrphanContentImages(oldContent, event.Content)
+	}
+	if canonicalURL, ok := formValue(r, "canonical_url"); ok {
+		// An empty value clears it, so the slug-based URL is canonical again
+		canonicalURL = strings.TrimSpace(canonicalURL)
+		if msg := canonicalURLError(canonicalURL); msg != "" {
+			utils.RespondValidationError(w, map[string]string{
+				"canonical_url": msg,
+			})
+			return
+		}
+		event.CanonicalURL = canonicalURL
+		updated["canonical_url"] = true
 	}
 	if slug := r.FormValue("slug"); slug != "" {
+		if utils.IsIDLike(slug) {
+			utils.RespondValidationError(w, map[string]string{
+				"slug": "Slug must not look like an ID",
+			})
+			return
+		}
+		if msg := fieldLengthError("slug", slug); msg != "" {
+			utils.RespondValidationError(w, map[string]string{
+				"slug": msg,
+			})
+			return
+		}
 		event.Slug = slug
 		updated["slug"] = true
 	}
@@ -441,31 +856,86 @@ func UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		event.Published = published == "true"
 		updated["published"] = true
 	}
+	if featured := r.FormValue("featured"); featured != "" {
+		event.Featured = featured == "true"
+		updated["featured"] = true
+	}
+	if value, ok := formValue(r, "publish_at"); ok {
+		// An empty value cancels the schedule
+		publishAt, valid := parsePublishAt(w, value)
+		if !valid {
+			return
+		}
+		event.PublishAt = publishAt
+		updated["publish_at"] = true
+	}
+	// Record when a draft goes live by hand; a pending schedule is moot now.
+	// Taking an item offline drops a schedule this request didn't set, or the
+	// scheduled publisher would bring it straight back.
+	if event.Published && !wasPublished {
+		now := time.Now()
+		event.PublishedAt = &now
+		event.PublishAt = nil
+	} else if !event.Published && wasPublished && !updated["publish_at"] {
+		event.PublishAt = nil
+	}
 	if eventStartStr := r.FormValue("event_start"); eventStartStr != "" {
-		parsedDate, err := time.Parse(time.RFC3339, eventStartStr)
+		parsedDate, err := utils.ParseDate(eventStartStr)
 		if err != nil {
-			// Try alternative format
-			parsedDate, err = time.Parse("2006-01-02", eventStartStr)
-			if err != nil {
-				utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid event_start format. Use RFC3339 or YYYY-MM-DD", nil)
-				return
-			}
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid event_start format. Use RFC3339 or YYYY-MM-DD", nil)
+			return
 		}
 		event.EventStart = &parsedDate
 		updated["event_start"] = true
 	}
 	if eventEndStr := r.FormValue("event_end"); eventEndStr != "" {
-		parsedDate, err := time.Parse(time.RFC3339, eventEndStr)
+		parsedDate, err := utils.ParseDate(eventEndStr)
 		if err != nil {
-			// Try alternative format
-			parsedDate, err = time.Parse("2006-01-02", eventEndStr)
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid event_end format. Use RFC3339 or YYYY-MM-DD", nil)
+			return
+		}
+		event.EventEnd = &parsedDate
+		updated["event_end"] = true
+	}
+	if location, ok := formValue(r, "location"); ok {
+		location = strings.TrimSpace(location)
+		if len(location) > 255 {
+			utils.RespondValidationError(w, map[string]string{
+				"location": "Location must be at most 255 characters",
+			})
+			return
+		}
+		event.Location = location
+		updated["location"] = true
+	}
+	latStr, hasLat := formValue(r, "latitude")
+	lngStr, hasLng := formValue(r, "longitude")
+	if hasLat || hasLng {
+		// Coordinates are updated as a pair; send both empty to remove the pin
+		fields := make(map[string]string)
+		latitude, longitude := parseCoordinates(latStr, lngStr, fields)
+		if len(fields) > 0 {
+			utils.RespondValidationError(w, fields)
+			return
+		}
+		event.Latitude = latitude
+		event.Longitude = longitude
+		updated["coordinates"] = true
+	}
+
+	// A draft's slug may follow its new title; published slugs never change implicitly
+	if updated["title"] && !updated["slug"] && !wasPublished && autoSlugOnTitle(r) {
+		if base := utils.GenerateSlug(event.Title); base != "" {
+			slug, err := utils.UniqueSlugExcluding(db, "events", base, event.ID)
 			if err != nil {
-				utils.RespondError(w, http.StatusBadRequest, "INVALID_DATE", "Invalid event_end format. Use RFC3339 or YYYY-MM-DD", nil)
+				utils.RespondInternalError(w)
 				return
 			}
+			if slug != event.Slug {
+				event.Slug = slug
+				updated["slug"] = true
+			}
 		}
-		event.EventEnd = &parsedDate
-		updated["event_end"] = true
 	}
 
 	// Handle image operations
@@ -473,16 +943,14 @@ func UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	file, header, err := r.FormFile("image")
 	hasNewImage := err == nil
 
+	// The replaced image is only deleted once the database save succeeds,
+	// and a freshly uploaded image is removed again if the save fails
+	var staleImageURL, newImageURL string
 	if deleteImage {
 		// Delete image without uploading new one
-		oldImageURL := event.ImageURL
+		staleImageURL = event.ImageURL
 		event.ImageURL = ""
 		updated["image_deleted"] = true
-
-		// Delete old image file
-		if oldImageURL != "" {
-			utils.DeleteImage(oldImageURL)
-		}
 	} else if hasNewImage {
 		// New image uploaded
 		defer file.Close()
@@ -490,37 +958,60 @@ func UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		// Validate and save the new image
 		imageResult, err := utils.SaveImage(file, header, "events")
 		if err != nil {
-			utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+			respondImageSaveError(w, r, err)
 			return
 		}
 
 		// Store old image URL for deletion
-		oldImageURL := event.ImageURL
+		staleImageURL = event.ImageURL
+		newImageURL = imageResult.URL
 		event.ImageURL = imageResult.URL
 		updated["image_updated"] = true
+	}
 
-		// Delete old image after new one is saved
-		if oldImageURL != "" {
-			utils.DeleteImage(oldImageURL)
-		}
+	// Publishing (now or scheduled) or removing the image of published
+	// content may require an image
+	publishing := event.Published || event.PublishAt != nil
+	if publishing && event.ImageURL == "" && (updated["published"] || updated["publish_at"] || updated["image_deleted"]) && utils.RequireImageToPublish("event") {
+		utils.RespondValidationError(w, map[string]string{
+			"image": "An image is required to publish",
+		})
+		return
 	}
 
-	// Save to database if any field was updated
-	oldSlug := event.Slug
+	// Save to database if any field was updated, recording a slug change
 	if len(updated) > 0 {
-		if err := db.Save(&event).Error; err != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Omit("view_count").Save(&event).Error; err != nil {
+				return err
+			}
+			return recordSlugChange(tx, "event", event.ID, oldSlug, event.Slug)
+		})
+		if err != nil {
+			// Don't leave the newly uploaded image behind as an orphan
+			utils.DeleteImage(newImageURL)
 			utils.RespondInternalError(w)
 			return
 		}
 
+		// Delete the replaced image now that the new state is persisted
+		utils.DeleteImage(staleImageURL)
+		// Delete inline images that were removed from the content
+		if updated["content"] {
+			utils.DeleteOrphanContentImages(oldContent, event.Content)
+		}
+
+		audit(r, "event.update", "event", event.ID, "fields="+updatedFields(updated))
+		if updated["publish_at"] {
+			utils.WakeScheduledPublisher()
+		}
+		if event.Published && !wasPublished {
+			utils.NotifyPublished("event", event.ID, event.Slug, event.Title)
+		}
+
 		// Invalidate caches
 		ctx := r.Context()
-		_ = utils.CacheDeletePattern(ctx, "event:list:*")
-		_ = utils.CacheDelete(ctx, utils.BuildCacheKey("event", "id", id))
-		_ = utils.CacheDelete(ctx, utils.BuildCacheKey("event", "slug", oldSlug))
-		if updated["slug"] && event.Slug != oldSlug {
-			_ = utils.CacheDelete(ctx, utils.BuildCacheKey("event", "slug", event.Slug))
-		}
+		utils.InvalidateContentCaches(ctx, "event", []string{id}, []string{oldSlug, event.Slug})
 	}
 
 	// Prepare response with updated event data
@@ -530,21 +1021,32 @@ func UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		Content:    event.Content,
 		Slug:       event.Slug,
 		Published:  event.Published,
+		Featured:   event.Featured,
 		ImageURL:   event.ImageURL,
 		AuthorID:   event.AuthorID,
-		Author: SimplifiedAuthor{
-			ID:   event.Author.ID,
-			Name: event.Author.Name,
-		},
+		Author:     simplifyAuthor(event.Author),
 		EventStart: event.EventStart,
 		EventEnd:   event.EventEnd,
+		Location:   event.Location,
+		Latitude:   event.Latitude,
+		Longitude:  event.Longitude,
 		CreatedAt:  event.CreatedAt,
 		UpdatedAt:  event.UpdatedAt,
+
+		CanonicalURL: event.CanonicalURL,
+		PublishedAt:  event.PublishedAt,
+	}
+
+	// Admins can ask where the images are stored
+	if includePaths(r) {
+		response.StoredFiles = storedFiles(response.ImageURL, response.Images)
 	}
 
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
 	response.ImageURL = utils.PrependBaseURL(response.ImageURL, baseURL)
+	prependGalleryBaseURL(response.Images, baseURL)
+	response.Status = eventStatus(response.EventStart, response.EventEnd, time.Now())
 
 	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
 		"message": "Event updated successfully",
@@ -553,14 +1055,14 @@ func UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	}, nil)
 }
 
-// DeleteEvent soft deletes an event and removes its image
+// DeleteEvent soft deletes an event, keeping its images until it is purged
 func DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
 	db := config.GetDB()
 	
-	// Get the event first to retrieve image URL
+	// Get the event first to make sure it exists
 	var event models.Event
 	if err := db.First(&event, "id = ?", id).Error; err != nil {
 		utils.RespondNotFound(w, "Event")
@@ -573,19 +1075,52 @@ func DeleteEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Delete the thumbnail image file
-	utils.DeleteImage(event.ImageURL)
+	// The image files and gallery are kept so the item can be restored from
+	// the trash; purging it removes them
 
-	// Delete all inline images embedded in the content (uploaded via rich text editor)
-	utils.DeleteContentImages(event.Content)
+	audit(r, "event.delete", "event", event.ID, "slug="+event.Slug)
 
 	// Invalidate caches
 	ctx := r.Context()
-	_ = utils.CacheDeletePattern(ctx, "event:list:*")
-	_ = utils.CacheDelete(ctx, utils.BuildCacheKey("event", "id", id))
-	_ = utils.CacheDelete(ctx, utils.BuildCacheKey("event", "slug", event.Slug))
+	utils.InvalidateContentCaches(ctx, "event", []string{id}, []string{event.Slug})
 
 	utils.RespondSuccess(w, http.StatusOK, map[string]string{
 		"message": "Event deleted successfully",
 	}, nil)
 }
+
+// EventYear is a year with published events and how many there are
+type EventYear struct {
+	Year  int   `json:"year"`
+	Count int64 `json:"count"`
+}
+
+// GetEventYears returns the years (by event_start) that have published events,
+// newest first, for browsing the event archive by year
+func GetEventYears(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Try cache first
+	cacheKey := utils.CacheKeyEventYears
+	var cached []EventYear
+	if err := cacheGet(ctx, r, cacheKey, &cached); err == nil {
+		utils.RespondSuccess(w, http.StatusOK, cached, nil)
+		return
+	}
+
+	// Cache miss - count published events per year in one grouped query
+	years := []EventYear{}
+	if err := config.GetDB().Model(&models.Event{}).
+		Select("CAST(EXTRACT(YEAR FROM event_start) AS INTEGER) AS year, COUNT(*) AS count").
+		Where("published = ? AND event_start IS NOT NULL", true).
+		Group("year").Order("year DESC").
+		Scan(&years).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Cache the result
+	_ = utils.CacheSet(ctx, cacheKey, years, utils.CacheTTLEventsList)
+
+	utils.RespondSuccess(w, http.StatusOK, years, nil)
+}
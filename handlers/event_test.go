@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"sentul-golf-be/models"
+)
+
+func TestApplyHidePastEvents(t *testing.T) {
+	db := setupTestDB(t)
+	author := createTestUser(t, db, "author@example.com", models.RoleUser)
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(offset time.Duration) *time.Time {
+		t := now.Add(offset)
+		return &t
+	}
+
+	events := []struct {
+		slug  string
+		start *time.Time
+		end   *time.Time
+	}{
+		{"ended-a-second-ago", at(-time.Hour), at(-time.Second)},
+		{"ends-now", at(-time.Hour), at(0)},
+		{"ends-in-a-second", at(-time.Hour), at(time.Second)},
+		{"started-without-end", at(-time.Second), nil},
+		{"starts-now-without-end", at(0), nil},
+		{"upcoming", at(time.Hour), at(2 * time.Hour)},
+		{"undated", nil, nil},
+	}
+	for _, e := range events {
+		event := models.Event{Title: e.slug, Content: "x", Slug: e.slug, AuthorID: author.ID, EventStart: e.start, EventEnd: e.end}
+		if err := db.Create(&event).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		hide bool
+		want []string
+	}{
+		{
+			name: "shown",
+			want: []string{"ended-a-second-ago", "ends-in-a-second", "ends-now", "started-without-end", "starts-now-without-end", "undated", "upcoming"},
+		},
+		{
+			name: "hidden",
+			hide: true,
+			want: []string{"ends-in-a-second", "ends-now", "starts-now-without-end", "undated", "upcoming"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var slugs []string
+			query := applyHidePastEvents(db.Model(&models.Event{}), tt.hide, now)
+			if err := query.Pluck("slug", &slugs).Error; err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(slugs)
+			if len(slugs) != len(tt.want) {
+				t.Fatalf("events = %v, want %v", slugs, tt.want)
+			}
+			for i := range tt.want {
+				if slugs[i] != tt.want[i] {
+					t.Fatalf("events = %v, want %v", slugs, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHidePastEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting string
+		role    models.Role // "" for an anonymous request
+		want    bool
+	}{
+		{"off by default", "", "", false},
+		{"anonymous", "true", "", true},
+		{"user", "true", models.RoleUser, true},
+		{"admin", "true", models.RoleAdmin, false},
+		{"disabled", "false", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HIDE_PAST_EVENTS", tt.setting)
+			r := httptest.NewRequest(http.MethodGet, "/api/events", nil)
+			if tt.role != "" {
+				r = withClaims(r, "user", tt.role)
+			}
+			if got := hidePastEvents(r); got != tt.want {
+				t.Errorf("hidePastEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		name       string
+		lat, lng   string
+		wantLat    float64
+		wantLng    float64
+		wantSet    bool
+		wantFields []string // Fields reported as invalid
+	}{
+		{name: "both empty", lat: " ", lng: ""},
+		{name: "valid", lat: "-6.5601", lng: "106.8865", wantLat: -6.5601, wantLng: 106.8865, wantSet: true},
+		{name: "bounds", lat: "90", lng: "-180", wantLat: 90, wantLng: -180, wantSet: true},
+		{name: "latitude only", lat: "-6.5601", wantFields: []string{"coordinates"}},
+		{name: "latitude out of range", lat: "90.1", lng: "0", wantFields: []string{"latitude"}},
+		{name: "longitude out of range", lat: "0", lng: "180.5", wantFields: []string{"longitude"}},
+		{name: "not a number", lat: "north", lng: "east", wantFields: []string{"latitude", "longitude"}},
+		{name: "NaN latitude", lat: "NaN", lng: "106.8865", wantFields: []string{"latitude"}},
+		{name: "NaN longitude", lat: "-6.5601", lng: "nan", wantFields: []string{"longitude"}},
+		{name: "infinite latitude", lat: "+Inf", lng: "106.8865", wantFields: []string{"latitude"}},
+		{name: "infinite longitude", lat: "-6.5601", lng: "-Infinity", wantFields: []string{"longitude"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := map[string]string{}
+			lat, lng := parseCoordinates(tt.lat, tt.lng, fields)
+
+			var gotFields []string
+			for field := range fields {
+				gotFields = append(gotFields, field)
+			}
+			sort.Strings(gotFields)
+			if len(gotFields) != len(tt.wantFields) || (len(gotFields) > 0 && !reflect.DeepEqual(gotFields, tt.wantFields)) {
+				t.Errorf("invalid fields = %v, want %v", gotFields, tt.wantFields)
+			}
+			if (lat != nil) != tt.wantSet || (lng != nil) != tt.wantSet {
+				t.Fatalf("parseCoordinates() = %v, %v, want set %v", lat, lng, tt.wantSet)
+			}
+			if tt.wantSet && (*lat != tt.wantLat || *lng != tt.wantLng) {
+				t.Errorf("parseCoordinates() = %v, %v, want %v, %v", *lat, *lng, tt.wantLat, tt.wantLng)
+			}
+		})
+	}
+}
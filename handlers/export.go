@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ExportNews returns a news article as a Markdown document with its metadata
+// as front matter. Only format=markdown (the default) is supported.
+func ExportNews(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "markdown"
+	}
+	if format != "markdown" {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_FORMAT", "Format must be 'markdown'", nil)
+		return
+	}
+
+	// Load the article with its author
+	id := mux.Vars(r)["id"]
+	db := config.GetDB()
+	var news models.News
+	if err := preloadAuthor(db, r).Where("id = ?", id).First(&news).Error; err != nil {
+		utils.RespondNotFound(w, "News")
+		return
+	}
+
+	// Convert the stored HTML to Markdown
+	body, err := utils.HTMLToMarkdown(news.Content, config.GetEnv("BASE_URL", ""))
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Build the document: front matter, title heading, then the content
+	var doc strings.Builder
+	doc.WriteString("---\n")
+	fmt.Fprintf(&doc, "title: %s\n", strconv.Quote(news.Title))
+	fmt.Fprintf(&doc, "slug: %s\n", strconv.Quote(news.Slug))
+	fmt.Fprintf(&doc, "author: %s\n", strconv.Quote(simplifyAuthor(news.Author).Name))
+	fmt.Fprintf(&doc, "published: %t\n", news.Published)
+	fmt.Fprintf(&doc, "created_at: %s\n", news.CreatedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&doc, "updated_at: %s\n", news.UpdatedAt.UTC().Format(time.RFC3339))
+	doc.WriteString("---\n\n")
+	fmt.Fprintf(&doc, "# %s\n\n", news.Title)
+	doc.WriteString(body)
+	doc.WriteString("\n")
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", news.Slug+".md"))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(doc.String()))
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// galleryUploadFolders maps gallery parent types to their upload subfolder
+var galleryUploadFolders = map[string]string{
+	"news":  "news",
+	"event": "events",
+}
+
+// preloadGallery loads the gallery images of a news article or event in gallery order
+func preloadGallery(query *gorm.DB) *gorm.DB {
+	return query.Preload("Images", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC, created_at ASC")
+	})
+}
+
+// prependGalleryBaseURL adds BASE_URL to the URL of every gallery image
+func prependGalleryBaseURL(images []models.Image, baseURL string) {
+	for i := range images {
+		images[i].URL = utils.PrependBaseURL(images[i].URL, baseURL)
+	}
+}
+
+// includePaths reports whether an admin asked for the storage details of
+// images with ?include_paths=true
+func includePaths(r *http.Request) bool {
+	return r.URL.Query().Get("include_paths") == "true" && isAdmin(r)
+}
+
+// storedFiles describes where the main image and gallery images are stored
+func storedFiles(imageURL string, images []models.Image) []utils.StoredFile {
+	files := []utils.StoredFile{}
+	if imageURL != "" {
+		files = append(files, utils.DescribeStoredFile(imageURL))
+	}
+	for _, image := range images {
+		files = append(files, utils.DescribeStoredFile(image.URL))
+	}
+	return files
+}
+
+// AddNewsImage uploads an image and appends it to a news article's gallery
+func AddNewsImage(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var news models.News
+	if err := config.GetDB().First(&news, "id = ?", id).Error; err != nil {
+		utils.RespondNotFound(w, "News")
+		return
+	}
+
+	addGalleryImage(w, r, "news", news.ID, news.Slug)
+}
+
+// DeleteNewsImage removes an image from a news article's gallery
+func DeleteNewsImage(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var news models.News
+	if err := config.GetDB().First(&news, "id = ?", id).Error; err != nil {
+		utils.RespondNotFound(w, "News")
+		return
+	}
+
+	deleteGalleryImage(w, r, "news", news.ID, news.Slug)
+}
+
+// AddEventImage uploads an image and appends it to an event's gallery
+func AddEventImage(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var event models.Event
+	if err := config.GetDB().First(&event, "id = ?", id).Error; err != nil {
+		utils.RespondNotFound(w, "Event")
+		return
+	}
+
+	addGalleryImage(w, r, "event", event.ID, event.Slug)
+}
+
+// DeleteEventImage removes an image from an event's gallery
+func DeleteEventImage(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var event models.Event
+	if err := config.GetDB().First(&event, "id = ?", id).Error; err != nil {
+		utils.RespondNotFound(w, "Event")
+		return
+	}
+
+	deleteGalleryImage(w, r, "event", event.ID, event.Slug)
+}
+
+// addGalleryImage saves the uploaded "image" file and appends it to the end
+// of the parent's gallery
+func addGalleryImage(w http.ResponseWriter, r *http.Request, parentType, parentID, parentSlug string) {
+	// Parse multipart form with max memory of 10MB
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondBadRequest(w, "Failed to parse form data")
+		return
+	}
+
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		utils.RespondBadRequest(w, "Image file is required")
+		return
+	}
+	defer file.Close()
+
+	// Validate and save the image
+	imageResult, err := utils.SaveImage(file, header, galleryUploadFolders[parentType])
+	if err != nil {
+		respondImageSaveError(w, r, err)
+		return
+	}
+
+	// Append after the current last image
+	db := config.GetDB()
+	image := models.Image{
+		ParentType: parentType,
+		ParentID:   parentID,
+		URL:        imageResult.URL,
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var maxIndex *int
+		if err := tx.Model(&models.Image{}).
+			Where("parent_type = ? AND parent_id = ?", parentType, parentID).
+			Select("MAX(order_index)").Scan(&maxIndex).Error; err != nil {
+			return err
+		}
+		if maxIndex != nil {
+			image.OrderIndex = *maxIndex + 1
+		}
+		return tx.Create(&image).Error
+	})
+	if err != nil {
+		// Don't leave the uploaded image behind as an orphan
+		utils.DeleteImage(imageResult.URL)
+		utils.RespondInternalError(w)
+		return
+	}
+
+	invalidateDetailCaches(r.Context(), parentType, parentID, parentSlug)
+
+	image.URL = utils.PrependBaseURL(image.URL, config.GetEnv("BASE_URL", ""))
+	utils.RespondSuccess(w, http.StatusCreated, image, nil)
+}
+
+// deleteGalleryImage removes one gallery image of the parent and its file
+func deleteGalleryImage(w http.ResponseWriter, r *http.Request, parentType, parentID, parentSlug string) {
+	imageID := mux.Vars(r)["imageId"]
+
+	db := config.GetDB()
+	var image models.Image
+	if err := db.Where("id = ? AND parent_type = ? AND parent_id = ?", imageID, parentType, parentID).First(&image).Error; err != nil {
+		utils.RespondNotFound(w, "Image")
+		return
+	}
+
+	if err := db.Delete(&image).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Delete the file now that the row is gone
+	utils.DeleteImage(image.URL)
+
+	invalidateDetailCaches(r.Context(), parentType, parentID, parentSlug)
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "Image deleted successfully",
+	}, nil)
+}
+
+// deleteGallery removes all gallery images of a parent and their files.
+// Failures are logged but don't fail the caller.
+func deleteGallery(db *gorm.DB, parentType, parentID string) {
+	var images []models.Image
+	if err := db.Where("parent_type = ? AND parent_id = ?", parentType, parentID).Find(&images).Error; err != nil {
+		fmt.Printf("Warning: failed to load gallery of %s %s: %v\n", parentType, parentID, err)
+		return
+	}
+	if len(images) == 0 {
+		return
+	}
+
+	if err := db.Delete(&images).Error; err != nil {
+		fmt.Printf("Warning: failed to delete gallery of %s %s: %v\n", parentType, parentID, err)
+		return
+	}
+	for _, image := range images {
+		utils.DeleteImage(image.URL)
+	}
+}
+
+// invalidateDetailCaches clears the cached detail views of a news article or event
+func invalidateDetailCaches(ctx context.Context, parentType, id, slug string) {
+	_ = utils.CacheDelete(ctx, utils.BuildCacheKey(parentType, "id", id))
+	_ = utils.CacheDelete(ctx, utils.BuildCacheKey(parentType, "slug", slug))
+}
@@ -1,56 +1,87 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"sentul-golf-be/config"
 	"sentul-golf-be/models"
 	"sentul-golf-be/utils"
 
 	"github.com/gorilla/mux"
+	"gorm.io/gorm"
 )
 
-// GetHoles retrieves all holes
+// invalidateHoleCaches clears the holes list, the scorecard, the navigation
+// and dashboard counts, and (when id is given) the cached detail of a single hole
+func invalidateHoleCaches(ctx context.Context, id string) {
+	_ = utils.CacheDelete(ctx, "holes:list")
+	_ = utils.CacheDelete(ctx, utils.BuildCacheKey("course", "scorecard"))
+	if id != "" {
+		_ = utils.CacheDelete(ctx, utils.BuildCacheKey("hole", id))
+	}
+	// Stats compare each hole with all the others, so any change affects them all
+	_ = utils.CacheDeletePattern(ctx, "hole:stats:*")
+	invalidateNavigationCache(ctx)
+	invalidateDashboardStats(ctx)
+}
+
+// HolesListResponse is the cached holes list with its course summary
+type HolesListResponse struct {
+	Holes   []models.Hole `json:"holes"`
+	Summary CourseSummary `json:"summary"`
+}
+
+// GetHoles retrieves all holes along with total and per-nine par and distance
 func GetHoles(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cacheKey := "holes:list"
-	
-	// Try to get from cache first
-	var holes []models.Hole
-	if err := utils.CacheGet(ctx, cacheKey, &holes); err == nil {
-		// Cache hit - add BASE_URL and return
-		baseURL := config.GetEnv("BASE_URL", "")
-		for i := range holes {
-			holes[i].ImageURL = utils.PrependBaseURL(holes[i].ImageURL, baseURL)
+
+	// Trash view: soft-deleted holes, for admins only since the route is public
+	if wantsTrash(r) {
+		if !isAdmin(r) {
+			utils.RespondForbidden(w, "Only admins can list deleted holes")
+			return
 		}
-		
-		utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
-			"holes": holes,
-		}, nil)
+		respondTrash(w, r, "holes", config.GetDB().Model(&models.Hole{}), "id, name AS title, deleted_at")
 		return
 	}
 	
-	// Cache miss - get from database
-	db := config.GetDB()
-	if err := db.Order("hole_index ASC").Find(&holes).Error; err != nil {
-		utils.RespondInternalError(w)
-		return
-	}
+	// Try to get from cache first
+	var response HolesListResponse
+	if err := cacheGet(ctx, r, cacheKey, &response); err != nil {
+		// Cache miss - get from database
+		db := config.GetDB()
+		if err := db.Order("hole_index ASC").Find(&response.Holes).Error; err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+		response.Summary = buildCourseSummary(response.Holes)
 
-	// Store in cache (without BASE_URL prepended)
-	_ = utils.CacheSet(ctx, cacheKey, holes, utils.CacheTTLHolesList)
+		// Store in cache (without BASE_URL prepended)
+		_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLHolesList)
+	}
 
 	// Add BASE_URL to all image URLs for response
 	baseURL := config.GetEnv("BASE_URL", "")
-	for i := range holes {
-		holes[i].ImageURL = utils.PrependBaseURL(holes[i].ImageURL, baseURL)
+	for i := range response.Holes {
+		response.Holes[i].ImageURL = utils.PrependBaseURL(response.Holes[i].ImageURL, baseURL)
 	}
 
-	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
-		"holes": holes,
-	}, nil)
+	utils.SetCacheControl(w, r, utils.CacheTTLHolesList)
+
+	// A bare list body has no room for the summary
+	if utils.ResponseStyle(r) == utils.ResponseStyleBare {
+		utils.RespondListETag(w, r, "holes", response.Holes, nil)
+		return
+	}
+	if response.Holes == nil {
+		response.Holes = []models.Hole{}
+	}
+	utils.RespondSuccessETag(w, r, response, nil)
 }
 
 // GetHole retrieves a single hole by ID
@@ -62,12 +93,13 @@ func GetHole(w http.ResponseWriter, r *http.Request) {
 
 	// Try to get from cache first
 	var hole models.Hole
-	if err := utils.CacheGet(ctx, cacheKey, &hole); err == nil {
+	if err := cacheGet(ctx, r, cacheKey, &hole); err == nil {
 		// Cache hit - add BASE_URL and return
 		baseURL := config.GetEnv("BASE_URL", "")
 		hole.ImageURL = utils.PrependBaseURL(hole.ImageURL, baseURL)
 		
-		utils.RespondSuccess(w, http.StatusOK, hole, nil)
+		utils.SetCacheControl(w, r, utils.CacheTTLHoleDetail)
+		utils.RespondSuccessETag(w, r, hole, nil)
 		return
 	}
 
@@ -85,7 +117,8 @@ func GetHole(w http.ResponseWriter, r *http.Request) {
 	baseURL := config.GetEnv("BASE_URL", "")
 	hole.ImageURL = utils.PrependBaseURL(hole.ImageURL, baseURL)
 
-	utils.RespondSuccess(w, http.StatusOK, hole, nil)
+	utils.SetCacheControl(w, r, utils.CacheTTLHoleDetail)
+	utils.RespondSuccessETag(w, r, hole, nil)
 }
 
 // CreateHole creates a new hole with image upload
@@ -101,6 +134,7 @@ func CreateHole(w http.ResponseWriter, r *http.Request) {
 	description := r.FormValue("description")
 	parStr := r.FormValue("par")
 	distanceStr := r.FormValue("distance")
+	db := config.GetDB()
 
 	// Validate required fields
 	fields := make(map[string]string)
@@ -129,6 +163,8 @@ func CreateHole(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	strokeIndex := parseStrokeIndex(db, r.FormValue("stroke_index"), "", fields)
+
 	if len(fields) > 0 {
 		utils.RespondValidationError(w, fields)
 		return
@@ -145,13 +181,12 @@ func CreateHole(w http.ResponseWriter, r *http.Request) {
 	// Validate and save the image
 	imageResult, err := utils.SaveImage(file, header, "holes")
 	if err != nil {
-		utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+		respondImageSaveError(w, r, err)
 		return
 	}
 
 	// Get max hole index
 	var maxIndex int
-	db := config.GetDB()
 	var lastHole models.Hole
 	if err := db.Order("hole_index DESC").First(&lastHole).Error; err == nil {
 		maxIndex = lastHole.HoleIndex
@@ -163,6 +198,7 @@ func CreateHole(w http.ResponseWriter, r *http.Request) {
 		Description: description,
 		Par:         par,
 		Distance:    distance,
+		StrokeIndex: strokeIndex,
 		HoleIndex:   maxIndex + 1,
 		ImageURL:    imageResult.URL,
 	}
@@ -176,8 +212,7 @@ func CreateHole(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Invalidate holes list cache
-	ctx := r.Context()
-	_ = utils.CacheDelete(ctx, "holes:list")
+	invalidateHoleCaches(r.Context(), "")
 
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
@@ -244,21 +279,30 @@ func UpdateHole(w http.ResponseWriter, r *http.Request) {
 		updated["distance"] = true
 	}
 
+	// An empty stroke_index clears it
+	if value, ok := formValue(r, "stroke_index"); ok {
+		fields := make(map[string]string)
+		hole.StrokeIndex = parseStrokeIndex(db, value, hole.ID, fields)
+		if len(fields) > 0 {
+			utils.RespondValidationError(w, fields)
+			return
+		}
+		updated["stroke_index"] = true
+	}
+
 	// Handle image operations
 	deleteImage := r.FormValue("delete_image") == "true"
 	file, header, err := r.FormFile("image")
 	hasNewImage := err == nil
 
+	// The replaced image is only deleted once the database save succeeds,
+	// and a freshly uploaded image is removed again if the save fails
+	var staleImageURL, newImageURL string
 	if deleteImage {
 		// Delete image without uploading new one
-		oldImageURL := hole.ImageURL
+		staleImageURL = hole.ImageURL
 		hole.ImageURL = ""
 		updated["image_deleted"] = true
-
-		// Delete old image file
-		if oldImageURL != "" {
-			utils.DeleteImage(oldImageURL)
-		}
 	} else if hasNewImage {
 		// New image uploaded
 		defer file.Close()
@@ -266,32 +310,31 @@ func UpdateHole(w http.ResponseWriter, r *http.Request) {
 		// Validate and save the new image
 		imageResult, err := utils.SaveImage(file, header, "holes")
 		if err != nil {
-			utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+			respondImageSaveError(w, r, err)
 			return
 		}
 
 		// Store old image URL for deletion
-		oldImageURL := hole.ImageURL
+		staleImageURL = hole.ImageURL
+		newImageURL = imageResult.URL
 		hole.ImageURL = imageResult.URL
 		updated["image_updated"] = true
-
-		// Delete old image after new one is saved
-		if oldImageURL != "" {
-			utils.DeleteImage(oldImageURL)
-		}
 	}
 
 	// Save to database if any field was updated
 	if len(updated) > 0 {
 		if err := db.Save(&hole).Error; err != nil {
+			// Don't leave the newly uploaded image behind as an orphan
+			utils.DeleteImage(newImageURL)
 			utils.RespondInternalError(w)
 			return
 		}
 
+		// Delete the replaced image now that the new state is persisted
+		utils.DeleteImage(staleImageURL)
+
 		// Invalidate caches
-		ctx := r.Context()
-		_ = utils.CacheDelete(ctx, "holes:list")
-		_ = utils.CacheDelete(ctx, utils.BuildCacheKey("hole", id))
+		invalidateHoleCaches(r.Context(), id)
 	}
 
 	// Add BASE_URL to response
@@ -305,14 +348,39 @@ func UpdateHole(w http.ResponseWriter, r *http.Request) {
 	}, nil)
 }
 
-// DeleteHole soft deletes a hole and removes its image
+// maxStrokeIndex is the highest stroke index on an 18-hole course
+const maxStrokeIndex = 18
+
+// parseStrokeIndex parses an optional stroke index, adding a message to fields
+// if it is out of range or already used by a hole other than holeID
+func parseStrokeIndex(db *gorm.DB, value, holeID string, fields map[string]string) *int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	strokeIndex, err := strconv.Atoi(value)
+	if err != nil || strokeIndex < 1 || strokeIndex > maxStrokeIndex {
+		fields["stroke_index"] = "Stroke index must be a number between 1 and 18"
+		return nil
+	}
+
+	var taken int64
+	if err := db.Model(&models.Hole{}).Where("stroke_index = ? AND id <> ?", strokeIndex, holeID).Count(&taken).Error; err == nil && taken > 0 {
+		fields["stroke_index"] = "Stroke index is already used by another hole"
+		return nil
+	}
+	return &strokeIndex
+}
+
+// DeleteHole soft deletes a hole, keeping its image until it is purged
 func DeleteHole(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
 	db := config.GetDB()
 
-	// Get the hole first to retrieve image URL
+	// Get the hole first to make sure it exists
 	var hole models.Hole
 	if err := db.First(&hole, "id = ?", id).Error; err != nil {
 		utils.RespondNotFound(w, "Hole")
@@ -325,13 +393,10 @@ func DeleteHole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Delete the image file
-	utils.DeleteImage(hole.ImageURL)
+	// The image file is kept so the hole can be restored; purging removes it
 
 	// Invalidate caches
-	ctx := r.Context()
-	_ = utils.CacheDelete(ctx, "holes:list")
-	_ = utils.CacheDelete(ctx, utils.BuildCacheKey("hole", id))
+	invalidateHoleCaches(r.Context(), id)
 
 	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
 		"message": "Hole deleted successfully",
@@ -356,7 +421,53 @@ func ReorderHoles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Every ID must appear once
+	seen := make(map[string]bool, len(req.HoleIDs))
+	for _, id := range req.HoleIDs {
+		if seen[id] {
+			utils.RespondValidationError(w, map[string]string{
+				"hole_ids": "Duplicate hole ID: " + id,
+			})
+			return
+		}
+		seen[id] = true
+	}
+
 	db := config.GetDB()
+
+	// The IDs must be exactly the existing, non-deleted holes: a partial
+	// list would leave the missing holes with clashing indices
+	var activeIDs []string
+	if err := db.Model(&models.Hole{}).Pluck("id", &activeIDs).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	active := make(map[string]bool, len(activeIDs))
+	for _, id := range activeIDs {
+		active[id] = true
+	}
+	var unknown, missing []string
+	for _, id := range req.HoleIDs {
+		if !active[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	for _, id := range activeIDs {
+		if !seen[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(unknown) > 0 || len(missing) > 0 {
+		fields := make(map[string]string)
+		if len(unknown) > 0 {
+			fields["hole_ids"] = "Unknown or deleted hole IDs: " + strings.Join(unknown, ", ")
+		}
+		if len(missing) > 0 {
+			fields["missing_hole_ids"] = "Holes missing from the list: " + strings.Join(missing, ", ")
+		}
+		utils.RespondValidationError(w, fields)
+		return
+	}
 	
 	// Start transaction
 	tx := db.Begin()
@@ -376,7 +487,8 @@ func ReorderHoles(w http.ResponseWriter, r *http.Request) {
 		// Index starts from 1
 		newIndex := i + 1
 		
-		if err := tx.Model(&models.Hole{}).Where("id = ?", id).Update("hole_index", newIndex).Error; err != nil {
+		// Scope to non-deleted holes explicitly so a hole deleted meanwhile is never touched
+		if err := tx.Model(&models.Hole{}).Where("id = ? AND deleted_at IS NULL", id).Update("hole_index", newIndex).Error; err != nil {
 			tx.Rollback()
 			utils.RespondInternalError(w)
 			return
@@ -389,10 +501,208 @@ func ReorderHoles(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Invalidate holes list cache (order changed)
-	ctx := r.Context()
-	_ = utils.CacheDelete(ctx, "holes:list")
+	invalidateHoleCaches(r.Context(), "")
 
 	utils.RespondSuccess(w, http.StatusOK, map[string]string{
 		"message": "Holes reordered successfully",
 	}, nil)
 }
+
+// HoleIndexReport describes whether hole indices form a continuous 1..N sequence
+type HoleIndexReport struct {
+	Count      int   `json:"count"`
+	Sequential bool  `json:"sequential"`
+	Missing    []int `json:"missing"`    // Indices in 1..N not used by any hole
+	Duplicates []int `json:"duplicates"` // Indices used by more than one hole
+}
+
+// buildHoleIndexReport checks holes (ordered by index) for gaps and duplicates
+func buildHoleIndexReport(holes []models.Hole) HoleIndexReport {
+	report := HoleIndexReport{
+		Count:      len(holes),
+		Missing:    []int{},
+		Duplicates: []int{},
+	}
+
+	used := make(map[int]int, len(holes))
+	for _, hole := range holes {
+		used[hole.HoleIndex]++
+		if used[hole.HoleIndex] == 2 {
+			report.Duplicates = append(report.Duplicates, hole.HoleIndex)
+		}
+	}
+	for i := 1; i <= len(holes); i++ {
+		if used[i] == 0 {
+			report.Missing = append(report.Missing, i)
+		}
+	}
+
+	report.Sequential = len(report.Missing) == 0 && len(report.Duplicates) == 0
+	return report
+}
+
+// CheckHoleIndices reports gaps or duplicates in the hole ordering without changing anything
+func CheckHoleIndices(w http.ResponseWriter, r *http.Request) {
+	db := config.GetDB()
+	var holes []models.Hole
+	if err := db.Order("hole_index ASC, created_at ASC").Find(&holes).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, buildHoleIndexReport(holes), nil)
+}
+
+// NormalizeHoleIndices reassigns sequential 1..N indices keeping the current order
+func NormalizeHoleIndices(w http.ResponseWriter, r *http.Request) {
+	db := config.GetDB()
+	var holes []models.Hole
+	var changed []string
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Order("hole_index ASC, created_at ASC").Find(&holes).Error; err != nil {
+			return err
+		}
+
+		// Index starts from 1
+		for i := range holes {
+			newIndex := i + 1
+			if holes[i].HoleIndex == newIndex {
+				continue
+			}
+			if err := tx.Model(&models.Hole{}).Where("id = ?", holes[i].ID).Update("hole_index", newIndex).Error; err != nil {
+				return err
+			}
+			holes[i].HoleIndex = newIndex
+			changed = append(changed, holes[i].ID)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Invalidate caches of the list and every renumbered hole
+	ctx := r.Context()
+	invalidateHoleCaches(ctx, "")
+	for _, id := range changed {
+		invalidateHoleCaches(ctx, id)
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"message": "Hole indices normalized successfully",
+		"updated": len(changed),
+		"report":  buildHoleIndexReport(holes),
+	}, nil)
+}
+
+// HoleStats places a hole within the course. Distance ranks start at 1 for
+// the longest, the par rank at 1 for the highest par and the difficulty rank
+// at 1 for the hardest hole. Holes that tie share a rank.
+type HoleStats struct {
+	HoleID          string `json:"hole_id"`
+	Par             int    `json:"par"`
+	Distance        int    `json:"distance"`
+	PlayableHoles   int    `json:"playable_holes"`    // Holes with a par set
+	DistanceRank    int    `json:"distance_rank"`     // Among all playable holes
+	Longest         bool   `json:"longest"`           // Longest playable hole
+	Shortest        bool   `json:"shortest"`          // Shortest playable hole
+	SameParHoles    int    `json:"same_par_holes"`    // Playable holes with the same par, including this one
+	ParDistanceRank int    `json:"par_distance_rank"` // Among holes with the same par
+	LongestOfPar    bool   `json:"longest_of_par"`    // e.g. the longest par 4
+	ShortestOfPar   bool   `json:"shortest_of_par"`   // e.g. the shortest par 4
+	ParRank         int    `json:"par_rank"`          // Among all playable holes
+
+	// Difficulty by stroke index, only for holes that have one
+	StrokeIndex    *int `json:"stroke_index,omitempty"`
+	RatedHoles     int  `json:"rated_holes"`               // Playable holes with a stroke index
+	DifficultyRank *int `json:"difficulty_rank,omitempty"` // Among rated holes
+}
+
+// GetHoleStats returns how a hole ranks by distance among all playable holes
+// and among the holes with the same par, by par, and by difficulty when it
+// has a stroke index
+func GetHoleStats(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	cacheKey := utils.BuildCacheKey("hole", "stats", id)
+
+	// Try to get from cache first
+	var stats HoleStats
+	if err := cacheGet(ctx, r, cacheKey, &stats); err == nil {
+		utils.RespondSuccess(w, http.StatusOK, stats, nil)
+		return
+	}
+
+	// Cache miss - get from database
+	db := config.GetDB()
+	var hole models.Hole
+	if err := db.First(&hole, "id = ?", id).Error; err != nil {
+		utils.RespondNotFound(w, "Hole")
+		return
+	}
+	if hole.Par <= 0 {
+		utils.RespondError(w, http.StatusUnprocessableEntity, "HOLE_NOT_PLAYABLE", "Hole has no par set", nil)
+		return
+	}
+
+	// Compare with every playable hole in one aggregate query
+	var agg struct {
+		Total          int
+		Longer         int
+		Shorter        int
+		SamePar        int
+		LongerSamePar  int
+		ShorterSamePar int
+		HigherPar      int
+		Rated          int
+		Harder         int
+	}
+	// Without a stroke index no hole counts as harder
+	strokeIndex := 0
+	if hole.StrokeIndex != nil {
+		strokeIndex = *hole.StrokeIndex
+	}
+	if err := db.Model(&models.Hole{}).Select(`COUNT(*) AS total,
+		COALESCE(SUM(CASE WHEN distance > ? THEN 1 ELSE 0 END), 0) AS longer,
+		COALESCE(SUM(CASE WHEN distance < ? THEN 1 ELSE 0 END), 0) AS shorter,
+		COALESCE(SUM(CASE WHEN par = ? THEN 1 ELSE 0 END), 0) AS same_par,
+		COALESCE(SUM(CASE WHEN par = ? AND distance > ? THEN 1 ELSE 0 END), 0) AS longer_same_par,
+		COALESCE(SUM(CASE WHEN par = ? AND distance < ? THEN 1 ELSE 0 END), 0) AS shorter_same_par,
+		COALESCE(SUM(CASE WHEN par > ? THEN 1 ELSE 0 END), 0) AS higher_par,
+		COALESCE(SUM(CASE WHEN stroke_index IS NOT NULL THEN 1 ELSE 0 END), 0) AS rated,
+		COALESCE(SUM(CASE WHEN stroke_index < ? THEN 1 ELSE 0 END), 0) AS harder`,
+		hole.Distance, hole.Distance, hole.Par, hole.Par, hole.Distance, hole.Par, hole.Distance, hole.Par, strokeIndex).
+		Where("par > 0").
+		Scan(&agg).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	stats = HoleStats{
+		HoleID:          hole.ID,
+		Par:             hole.Par,
+		Distance:        hole.Distance,
+		PlayableHoles:   agg.Total,
+		DistanceRank:    agg.Longer + 1,
+		Longest:         agg.Longer == 0,
+		Shortest:        agg.Shorter == 0,
+		SameParHoles:    agg.SamePar,
+		ParDistanceRank: agg.LongerSamePar + 1,
+		LongestOfPar:    agg.LongerSamePar == 0,
+		ShortestOfPar:   agg.ShorterSamePar == 0,
+		ParRank:         agg.HigherPar + 1,
+		StrokeIndex:     hole.StrokeIndex,
+		RatedHoles:      agg.Rated,
+	}
+	if hole.StrokeIndex != nil {
+		rank := agg.Harder + 1
+		stats.DifficultyRank = &rank
+	}
+
+	// Store in cache alongside the hole detail
+	_ = utils.CacheSet(ctx, cacheKey, stats, utils.CacheTTLHoleDetail)
+
+	utils.RespondSuccess(w, http.StatusOK, stats, nil)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"sentul-golf-be/models"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// strokeIndex returns a pointer to a stroke index
+func strokeIndex(i int) *int {
+	return &i
+}
+
+// seedHoles inserts the holes in order and returns their IDs by name
+func seedHoles(t *testing.T, db *gorm.DB, holes []models.Hole) map[string]string {
+	t.Helper()
+
+	ids := make(map[string]string, len(holes))
+	for i, hole := range holes {
+		hole.HoleIndex = i + 1
+		if err := db.Create(&hole).Error; err != nil {
+			t.Fatal(err)
+		}
+		ids[hole.Name] = hole.ID
+	}
+	return ids
+}
+
+func TestGetHoleStats(t *testing.T) {
+	holes := []models.Hole{
+		{Name: "Hole 1", Par: 4, Distance: 400, StrokeIndex: strokeIndex(1)},
+		{Name: "Hole 2", Par: 3, Distance: 150, StrokeIndex: strokeIndex(3)},
+		{Name: "Hole 3", Par: 5, Distance: 520, StrokeIndex: strokeIndex(2)},
+		{Name: "Hole 4", Par: 4, Distance: 350},
+		{Name: "Practice", Distance: 600, StrokeIndex: strokeIndex(4)}, // Not playable without a par
+	}
+
+	tests := []struct {
+		hole string
+		want HoleStats
+	}{
+		{"Hole 1", HoleStats{
+			Par: 4, Distance: 400, PlayableHoles: 4, DistanceRank: 2,
+			SameParHoles: 2, ParDistanceRank: 1, LongestOfPar: true, ParRank: 2,
+			StrokeIndex: strokeIndex(1), RatedHoles: 3, DifficultyRank: strokeIndex(1),
+		}},
+		{"Hole 2", HoleStats{
+			Par: 3, Distance: 150, PlayableHoles: 4, DistanceRank: 4, Shortest: true,
+			SameParHoles: 1, ParDistanceRank: 1, LongestOfPar: true, ShortestOfPar: true, ParRank: 4,
+			StrokeIndex: strokeIndex(3), RatedHoles: 3, DifficultyRank: strokeIndex(3),
+		}},
+		{"Hole 3", HoleStats{
+			Par: 5, Distance: 520, PlayableHoles: 4, DistanceRank: 1, Longest: true,
+			SameParHoles: 1, ParDistanceRank: 1, LongestOfPar: true, ShortestOfPar: true, ParRank: 1,
+			StrokeIndex: strokeIndex(2), RatedHoles: 3, DifficultyRank: strokeIndex(2),
+		}},
+		{"Hole 4", HoleStats{
+			Par: 4, Distance: 350, PlayableHoles: 4, DistanceRank: 3,
+			SameParHoles: 2, ParDistanceRank: 2, ShortestOfPar: true, ParRank: 2,
+			RatedHoles: 3,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hole, func(t *testing.T) {
+			db := setupTestDB(t)
+			setupTestRedis(t)
+			ids := seedHoles(t, db, holes)
+			id := ids[tt.hole]
+
+			for _, read := range []string{"first", "cached"} {
+				r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/api/holes/"+id+"/stats", nil), map[string]string{"id": id})
+				w := httptest.NewRecorder()
+				GetHoleStats(w, r)
+				if w.Code != http.StatusOK {
+					t.Fatalf("%s read: status = %d, want %d (body %s)", read, w.Code, http.StatusOK, w.Body.String())
+				}
+
+				var body struct {
+					Data HoleStats `json:"data"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+					t.Fatal(err)
+				}
+				want := tt.want
+				want.HoleID = id
+				if !reflect.DeepEqual(body.Data, want) {
+					t.Errorf("%s read: stats = %+v, want %+v", read, body.Data, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHoleStrokeIndex(t *testing.T) {
+	holeFields := map[string]string{"name": "Hole 3", "par": "5", "distance": "520"}
+	withStrokeIndex := func(value string) map[string]string {
+		fields := map[string]string{"stroke_index": value}
+		for key, v := range holeFields {
+			fields[key] = v
+		}
+		return fields
+	}
+
+	tests := []struct {
+		name       string
+		create     bool // CreateHole, otherwise UpdateHole on Hole 2
+		fields     map[string]string
+		wantStatus int
+		want       *int // Stroke index stored afterwards
+	}{
+		{"create with stroke index", true, withStrokeIndex("5"), http.StatusCreated, strokeIndex(5)},
+		{"create without stroke index", true, holeFields, http.StatusCreated, nil},
+		{"create with a taken stroke index", true, withStrokeIndex("1"), http.StatusUnprocessableEntity, nil},
+		{"update sets it", false, map[string]string{"stroke_index": "7"}, http.StatusOK, strokeIndex(7)},
+		{"update keeps its own", false, map[string]string{"stroke_index": "2"}, http.StatusOK, strokeIndex(2)},
+		{"update clears it", false, map[string]string{"stroke_index": ""}, http.StatusOK, nil},
+		{"update leaves it alone", false, map[string]string{"name": "Signature"}, http.StatusOK, strokeIndex(2)},
+		{"update with a taken stroke index", false, map[string]string{"stroke_index": "1"}, http.StatusUnprocessableEntity, strokeIndex(2)},
+		{"out of range", false, map[string]string{"stroke_index": "19"}, http.StatusUnprocessableEntity, strokeIndex(2)},
+		{"zero", false, map[string]string{"stroke_index": "0"}, http.StatusUnprocessableEntity, strokeIndex(2)},
+		{"not a number", false, map[string]string{"stroke_index": "hard"}, http.StatusUnprocessableEntity, strokeIndex(2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			setupTestUploads(t)
+			ids := seedHoles(t, db, []models.Hole{
+				{Name: "Hole 1", Par: 4, Distance: 400, StrokeIndex: strokeIndex(1)},
+				{Name: "Hole 2", Par: 3, Distance: 150, StrokeIndex: strokeIndex(2)},
+			})
+
+			w := httptest.NewRecorder()
+			if tt.create {
+				CreateHole(w, newFormRequest(t, http.MethodPost, "/api/admin/holes", tt.fields, testPNG(t)))
+			} else {
+				id := ids["Hole 2"]
+				r := newFormRequest(t, http.MethodPut, "/api/admin/holes/"+id, tt.fields, nil)
+				UpdateHole(w, mux.SetURLVars(r, map[string]string{"id": id}))
+			}
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusUnprocessableEntity {
+				if got := errorCode(t, w); got != "VALIDATION_ERROR" {
+					t.Errorf("error code = %q, want VALIDATION_ERROR", got)
+				}
+			}
+
+			var hole models.Hole
+			query := db.Where("id = ?", ids["Hole 2"])
+			if tt.create {
+				query = db.Where("name = ?", "Hole 3")
+			}
+			if err := query.First(&hole).Error; err != nil {
+				if tt.create && tt.wantStatus != http.StatusCreated {
+					return // Nothing was created
+				}
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(hole.StrokeIndex, tt.want) {
+				t.Errorf("stroke_index = %v, want %v", derefInt(hole.StrokeIndex), derefInt(tt.want))
+			}
+		})
+	}
+}
+
+// derefInt formats an optional int for test messages
+func derefInt(i *int) interface{} {
+	if i == nil {
+		return nil
+	}
+	return *i
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"gorm.io/gorm"
+)
+
+// HomeResponse bundles everything the public homepage shows
+type HomeResponse struct {
+	LatestNews     []PostResponse `json:"latest_news"`
+	LatestEvents   []PostResponse `json:"latest_events"`
+	UpcomingEvents []PostResponse `json:"upcoming_events"`
+	Featured       []PostResponse `json:"featured"` // Published news and events with the featured flag, newest first
+}
+
+// homeSectionLimit returns how many items each homepage section holds, from
+// HOME_SECTION_LIMIT (default 5)
+func homeSectionLimit() int {
+	if v, err := strconv.Atoi(config.GetEnv("HOME_SECTION_LIMIT", "")); err == nil && v > 0 && v <= utils.MaxPageLimit {
+		return v
+	}
+	return 5
+}
+
+// GetHome returns the newest news, newest events, upcoming events and
+// featured posts (published posts flagged featured) in one response
+func GetHome(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	limit := homeSectionLimit()
+	hidePast := hidePastEvents(r)
+	now := time.Now()
+
+	// Try cache first
+	cacheKey := utils.BuildCacheKey(utils.CacheKeyHome, "limit", limit)
+	if hidePast {
+		cacheKey = utils.BuildCacheKey(cacheKey, "hide_past")
+	}
+	var cached HomeResponse
+	if err := cacheGet(ctx, r, cacheKey, &cached); err == nil {
+		respondHome(w, cached)
+		return
+	}
+
+	// Cache miss - get from database
+	db := config.GetDB()
+	publishedNews := func() *gorm.DB {
+		return db.Preload("Author").Where("published = ?", true)
+	}
+	publishedEvents := func() *gorm.DB {
+		return applyHidePastEvents(db.Preload("Author").Where("published = ?", true), hidePast, now)
+	}
+
+	var latestNews, featuredNews []models.News
+	var latestEvents, upcomingEvents, featuredEvents []models.Event
+	if err := publishedNews().Order("created_at DESC, id DESC").Limit(limit).Find(&latestNews).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	if err := publishedEvents().Order("created_at DESC, id DESC").Limit(limit).Find(&latestEvents).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	// Upcoming includes events in progress, soonest first
+	if err := applyEventStatus(publishedEvents(), EventStatusUpcoming, now).
+		Order("COALESCE(event_start, event_end) ASC, id ASC").Limit(limit).Find(&upcomingEvents).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	if err := publishedNews().Where("featured = ?", true).
+		Order("created_at DESC, id DESC").Limit(limit).Find(&featuredNews).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	if err := publishedEvents().Where("featured = ?", true).
+		Order("created_at DESC, id DESC").Limit(limit).Find(&featuredEvents).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	home := HomeResponse{
+		LatestNews:     newsToPosts(latestNews),
+		LatestEvents:   eventsToPosts(latestEvents),
+		UpcomingEvents: eventsToPosts(upcomingEvents),
+	}
+
+	// Merge featured news and events, newest first
+	featured := append(newsToPosts(featuredNews), eventsToPosts(featuredEvents)...)
+	sort.SliceStable(featured, func(i, j int) bool {
+		return featured[i].CreatedAt.After(featured[j].CreatedAt)
+	})
+	if len(featured) > limit {
+		featured = featured[:limit]
+	}
+	home.Featured = featured
+
+	// Cache the result briefly since upcoming events change with time
+	_ = utils.CacheSet(ctx, cacheKey, home, utils.CacheTTLHome)
+
+	respondHome(w, home)
+}
+
+// newsToPosts converts news articles to the unified post shape
+func newsToPosts(news []models.News) []PostResponse {
+	posts := make([]PostResponse, len(news))
+	for i, n := range news {
+		posts[i] = newsToPost(n)
+	}
+	return posts
+}
+
+// eventsToPosts converts events to the unified post shape
+func eventsToPosts(events []models.Event) []PostResponse {
+	posts := make([]PostResponse, len(events))
+	for i, e := range events {
+		posts[i] = eventToPost(e)
+	}
+	return posts
+}
+
+// respondHome prepends BASE_URL to every image URL and sends the homepage
+func respondHome(w http.ResponseWriter, home HomeResponse) {
+	baseURL := config.GetEnv("BASE_URL", "")
+	for _, section := range [][]PostResponse{home.LatestNews, home.LatestEvents, home.UpcomingEvents, home.Featured} {
+		for i := range section {
+			section[i].ImageURL = utils.PrependBaseURL(section[i].ImageURL, baseURL)
+		}
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, home, nil)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"sentul-golf-be/models"
+)
+
+func TestGetHomeFeatured(t *testing.T) {
+	tests := []struct {
+		name  string
+		news  []models.News
+		event []models.Event
+		want  []string // Slugs of the featured section
+	}{
+		{
+			name: "nothing featured",
+			news: []models.News{{Title: "Plain", Slug: "plain", Published: true}},
+			want: []string{},
+		},
+		{
+			name: "featured news and events newest first",
+			news: []models.News{
+				{Title: "Old pin", Slug: "old-pin", Published: true, Featured: true},
+				{Title: "Plain", Slug: "plain", Published: true},
+			},
+			event: []models.Event{{Title: "New pin", Slug: "new-pin", Published: true, Featured: true}},
+			want:  []string{"new-pin", "old-pin"},
+		},
+		{
+			name: "featured drafts stay hidden",
+			news: []models.News{{Title: "Draft pin", Slug: "draft-pin", Featured: true}},
+			want: []string{},
+		},
+		{
+			name: "a featured tag no longer features",
+			news: []models.News{{Title: "Tagged", Slug: "tagged", Published: true, Tags: []models.Tag{{Name: "Featured", Slug: "featured"}}}},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			cache := setupTestRedis(t)
+			admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+
+			// Insert in order with distinct creation times
+			created := time.Now().Add(-time.Hour)
+			for _, news := range tt.news {
+				news.Content, news.AuthorID, news.CreatedAt = "x", admin.ID, created
+				if err := db.Create(&news).Error; err != nil {
+					t.Fatal(err)
+				}
+				created = created.Add(time.Minute)
+			}
+			for _, event := range tt.event {
+				event.Content, event.AuthorID, event.CreatedAt = "x", admin.ID, created
+				if err := db.Create(&event).Error; err != nil {
+					t.Fatal(err)
+				}
+				created = created.Add(time.Minute)
+			}
+
+			w := httptest.NewRecorder()
+			GetHome(w, httptest.NewRequest(http.MethodGet, "/api/home", nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+			}
+
+			var body struct {
+				Data HomeResponse `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, post := range body.Data.Featured {
+				got = append(got, post.Slug)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("featured = %v, want %v", got, tt.want)
+			}
+			if !cache.Exists("home:limit:5") {
+				t.Errorf("home not cached under home:limit:5, keys %v", cache.Keys())
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+)
+
+// NavigationResponse tells the frontend which menu sections have content
+type NavigationResponse struct {
+	HasNews             bool  `json:"has_news"`
+	NewsCount           int64 `json:"news_count"` // Published news
+	HasEvents           bool  `json:"has_events"`
+	EventCount          int64 `json:"event_count"` // Published events
+	HasUpcomingEvents   bool  `json:"has_upcoming_events"`
+	UpcomingEventsCount int64 `json:"upcoming_events_count"` // Including events in progress
+	HoleCount           int64 `json:"hole_count"`
+	HasAmenities        bool  `json:"has_amenities"`
+	AmenityCount        int64 `json:"amenity_count"` // Active amenities
+}
+
+// invalidateNavigationCache clears every cached navigation variant after a
+// hole or amenity change; content changes clear them through
+// utils.InvalidateContentCaches
+func invalidateNavigationCache(ctx context.Context) {
+	_ = utils.CacheDelete(ctx, utils.CacheKeyNavigation)
+	_ = utils.CacheDeletePattern(ctx, utils.CacheKeyNavigation+":*")
+}
+
+// GetNavigation returns counts and availability flags for the main sections
+// of the site, so the frontend can build its menu without probing each one
+func GetNavigation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	hidePast := hidePastEvents(r)
+	now := time.Now()
+
+	// Try cache first
+	cacheKey := utils.CacheKeyNavigation
+	if hidePast {
+		cacheKey = utils.BuildCacheKey(cacheKey, "hide_past")
+	}
+	var nav NavigationResponse
+	if err := cacheGet(ctx, r, cacheKey, &nav); err == nil {
+		utils.RespondSuccess(w, http.StatusOK, nav, nil)
+		return
+	}
+
+	// Cache miss - count each section
+	db := config.GetDB()
+	if err := db.Model(&models.News{}).Where("published = ?", true).Count(&nav.NewsCount).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	publishedEvents := applyHidePastEvents(db.Model(&models.Event{}).Where("published = ?", true), hidePast, now)
+	if err := publishedEvents.Count(&nav.EventCount).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	upcomingEvents := applyEventStatus(db.Model(&models.Event{}).Where("published = ?", true), EventStatusUpcoming, now)
+	if err := upcomingEvents.Count(&nav.UpcomingEventsCount).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	if err := db.Model(&models.Hole{}).Count(&nav.HoleCount).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	if err := db.Model(&models.Amenity{}).Where("active = ?", true).Count(&nav.AmenityCount).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	nav.HasNews = nav.NewsCount > 0
+	nav.HasEvents = nav.EventCount > 0
+	nav.HasUpcomingEvents = nav.UpcomingEventsCount > 0
+	nav.HasAmenities = nav.AmenityCount > 0
+
+	// Cache briefly since upcoming events change with time
+	_ = utils.CacheSet(ctx, cacheKey, nav, utils.CacheTTLNavigation)
+
+	utils.RespondSuccess(w, http.StatusOK, nav, nil)
+}
@@ -1,8 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
+	"strings"
 	"time"
 
 	"sentul-golf-be/config"
@@ -11,12 +12,14 @@ import (
 	"sentul-golf-be/utils"
 
 	"github.com/gorilla/mux"
+	"gorm.io/gorm"
 )
 
 // SimplifiedAuthor for response
 type SimplifiedAuthor struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted,omitempty"` // Only set for admins viewing a soft-deleted author
 }
 
 // NewsResponse with simplified author (for list)
@@ -25,6 +28,7 @@ type NewsResponse struct {
 	Title     string           `json:"title"`
 	Slug      string           `json:"slug"`
 	Published bool             `json:"published"`
+	Featured  bool             `json:"featured"`
 	ImageURL  string           `json:"image_url"`
 	AuthorID  string           `json:"author_id"`
 	Author    SimplifiedAuthor `json:"author"`
@@ -39,74 +43,103 @@ type NewsDetailResponse struct {
 	Content   string           `json:"content"`
 	Slug      string           `json:"slug"`
 	Published bool             `json:"published"`
+	Featured  bool             `json:"featured"`
 	ImageURL  string           `json:"image_url"`
 	AuthorID  string           `json:"author_id"`
 	Author    SimplifiedAuthor `json:"author"`
+	Tags      []models.Tag     `json:"tags"`
+	Images    []models.Image   `json:"images"` // Gallery, in order
+	ViewCount int64            `json:"view_count"`
+	PublishAt *time.Time       `json:"publish_at,omitempty"`
 	CreatedAt time.Time        `json:"created_at"`
 	UpdatedAt time.Time        `json:"updated_at"`
+
+	// Original URL of syndicated content for rel="canonical"; empty means
+	// the slug-based URL is canonical
+	CanonicalURL string `json:"canonical_url"`
+
+	// When the item last went live; publish_at only holds a pending schedule
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Storage details of the images, admins with ?include_paths=true only
+	StoredFiles []utils.StoredFile `json:"stored_files,omitempty"`
 }
 
 // GetNews retrieves all news articles with pagination
 func GetNews(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	
-	// Get pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-	
-	page := 1
-	limit := 10 // Default 10 items per page
-	
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-	
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
-		}
+
+	// Trash view: soft-deleted articles (the route is admin-only)
+	if wantsTrash(r) {
+		respondTrash(w, r, "news", config.GetDB().Model(&models.News{}), "id, title, slug, deleted_at")
+		return
 	}
 	
-	offset := (page - 1) * limit
+	// Get pagination parameters
+	page, limit, offset := utils.ParsePagination(r, utils.DefaultPageLimit)
 
 	// Filter by published status if not admin
 	claims, ok := ctx.Value(middleware.UserContextKey).(*utils.Claims)
 	publishedOnly := !ok || claims.Role != string(models.RoleAdmin)
 	
+	// Optional keyword search on title and excerpt
+	search := searchParam(r)
+	// Optional tag filter
+	tags, valid := tagFilterParam(w, r)
+	if !valid {
+		return
+	}
+	// Optional pinned-only filter
+	featured := featuredOnly(r)
+
 	// Try cache first
 	cacheKey := utils.BuildCacheKey("news", "list", "page", page, "limit", limit, "published", publishedOnly)
+	cacheKey = tags.cacheKey(cacheKey)
+	if featured {
+		cacheKey = utils.BuildCacheKey(cacheKey, "featured")
+	}
+	if search != "" {
+		cacheKey = utils.BuildCacheKey(cacheKey, "search", search)
+	}
 	type CachedNewsResponse struct {
 		NewsResponse []NewsResponse `json:"news"`
 		Meta         *utils.Meta    `json:"meta"`
 	}
 	var cached CachedNewsResponse
-	if err := utils.CacheGet(ctx, cacheKey, &cached); err == nil {
-		utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
-			"news": cached.NewsResponse,
-		}, cached.Meta)
+	if err := cacheGet(ctx, r, cacheKey, &cached); err == nil {
+		baseURL := config.GetEnv("BASE_URL", "")
+		for i := range cached.NewsResponse {
+			cached.NewsResponse[i].ImageURL = utils.PrependBaseURL(cached.NewsResponse[i].ImageURL, baseURL)
+		}
+		utils.RespondList(w, r, "news", cached.NewsResponse, cached.Meta)
 		return
 	}
 	
 	// Cache miss - get from database
 	db := config.GetDB()
 	var news []models.News
-	query := db.Preload("Author")
+	query := preloadAuthor(db, r)
 	if publishedOnly {
 		query = query.Where("published = ?", true)
 	}
+	query = applySearch(query, search)
+	query = applyTagFilter(query, newsTags, tags)
+	query = applyFeatured(query, featured)
 	
 	// Count total items
 	var total int64
 	countQuery := db.Model(&models.News{})
+	countQuery = applySearch(countQuery, search)
+	countQuery = applyTagFilter(countQuery, newsTags, tags)
+	countQuery = applyFeatured(countQuery, featured)
 	if !ok || claims.Role != string(models.RoleAdmin) {
 		countQuery = countQuery.Where("published = ?", true)
 	}
+	countQuery = countQuery.Session(&gorm.Session{})
 	countQuery.Count(&total)
 	
 	// Get paginated results
-	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&news).Error; err != nil {
+	if err := query.Order(listOrder()).Limit(limit).Offset(offset).Find(&news).Error; err != nil {
 		utils.RespondInternalError(w)
 		return
 	}
@@ -119,45 +152,35 @@ func GetNews(w http.ResponseWriter, r *http.Request) {
 			Title:     n.Title,
 			Slug:      n.Slug,
 			Published: n.Published,
+			Featured:  n.Featured,
 			ImageURL:  n.ImageURL,
 			AuthorID:  n.AuthorID,
-			Author: SimplifiedAuthor{
-				ID:   n.Author.ID,
-				Name: n.Author.Name,
-			},
+			Author:    simplifyAuthor(n.Author),
 			CreatedAt: n.CreatedAt,
 			UpdatedAt: n.UpdatedAt,
 		}
 	}
 	
-	// Calculate total pages
-	totalPages := int(total) / limit
-	if int(total)%limit != 0 {
-		totalPages++
+	meta := utils.NewMeta(page, limit, total)
+	if !publishedOnly {
+		addPublishedBreakdown(meta, countQuery)
 	}
 
-	meta := &utils.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      int(total),
-		TotalPages: totalPages,
+	// Cache the response (skip empty search results so misses aren't cached)
+	if search == "" || len(newsResponse) > 0 {
+		_ = utils.CacheSet(ctx, cacheKey, CachedNewsResponse{
+			NewsResponse: newsResponse,
+			Meta:         meta,
+		}, utils.CacheTTLNewsList)
 	}
 
-	// Cache the response
-	_ = utils.CacheSet(ctx, cacheKey, CachedNewsResponse{
-		NewsResponse: newsResponse,
-		Meta:         meta,
-	}, utils.CacheTTLNewsList)
-
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
 	for i := range newsResponse {
 		newsResponse[i].ImageURL = utils.PrependBaseURL(newsResponse[i].ImageURL, baseURL)
 	}
 
-	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
-		"news": newsResponse,
-	}, meta)
+	utils.RespondList(w, r, "news", newsResponse, meta)
 }
 
 // GetNewsBySlug retrieves a single news article by slug
@@ -167,17 +190,24 @@ func GetNewsBySlug(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cacheKey := utils.BuildCacheKey("news", "slug", slug)
 
+	// Admins may see soft-deleted authors, so their view is never cached
+	admin := isAdmin(r)
+
 	// Try cache first
 	var response NewsDetailResponse
-	if err := utils.CacheGet(ctx, cacheKey, &response); err == nil {
-		utils.RespondSuccess(w, http.StatusOK, response, nil)
+	if !admin && cacheGet(ctx, r, cacheKey, &response) == nil {
+		utils.IncrementViewCount(ctx, "news", response.ID)
+		response.ImageURL = utils.PrependBaseURL(response.ImageURL, config.GetEnv("BASE_URL", ""))
+		prependGalleryBaseURL(response.Images, config.GetEnv("BASE_URL", ""))
+		utils.SetCacheControl(w, r, utils.CacheTTLNewsDetail)
+		utils.RespondSuccessETag(w, r, response, nil)
 		return
 	}
 
 	// Cache miss - get from database
 	db := config.GetDB()
 	var news models.News
-	if err := db.Preload("Author").Where("slug = ?", slug).First(&news).Error; err != nil {
+	if err := publishedOnlyFor(preloadGallery(preloadAuthor(db, r).Preload("Tags")), r).Where("slug = ?", slug).First(&news).Error; err != nil {
 		utils.RespondNotFound(w, "News")
 		return
 	}
@@ -189,24 +219,41 @@ func GetNewsBySlug(w http.ResponseWriter, r *http.Request) {
 		Content:   news.Content,
 		Slug:      news.Slug,
 		Published: news.Published,
+		Featured:  news.Featured,
 		ImageURL:  news.ImageURL,
 		AuthorID:  news.AuthorID,
-		Author: SimplifiedAuthor{
-			ID:   news.Author.ID,
-			Name: news.Author.Name,
-		},
+		Author:    simplifyAuthor(news.Author),
+		Tags:      news.Tags,
+		Images:    news.Images,
+		ViewCount: news.ViewCount,
+		PublishAt: news.PublishAt,
 		CreatedAt: news.CreatedAt,
 		UpdatedAt: news.UpdatedAt,
+
+		CanonicalURL: news.CanonicalURL,
+		PublishedAt:  news.PublishedAt,
 	}
 
+	// Count the view
+	utils.IncrementViewCount(ctx, "news", response.ID)
+
 	// Cache the response
-	_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLNewsDetail)
+	if !admin {
+		_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLNewsDetail)
+	}
+
+	// Admins can ask where the images are stored
+	if includePaths(r) {
+		response.StoredFiles = storedFiles(response.ImageURL, response.Images)
+	}
 
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
 	response.ImageURL = utils.PrependBaseURL(response.ImageURL, baseURL)
+	prependGalleryBaseURL(response.Images, baseURL)
 
-	utils.RespondSuccess(w, http.StatusOK, response, nil)
+	utils.SetCacheControl(w, r, utils.CacheTTLNewsDetail)
+	utils.RespondSuccessETag(w, r, response, nil)
 }
 
 // GetNewsByID retrieves a single news article by ID
@@ -216,17 +263,24 @@ func GetNewsByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cacheKey := utils.BuildCacheKey("news", "id", id)
 
+	// Admins may see soft-deleted authors, so their view is never cached
+	admin := isAdmin(r)
+
 	// Try cache first
 	var response NewsDetailResponse
-	if err := utils.CacheGet(ctx, cacheKey, &response); err == nil {
-		utils.RespondSuccess(w, http.StatusOK, response, nil)
+	if !admin && cacheGet(ctx, r, cacheKey, &response) == nil {
+		utils.IncrementViewCount(ctx, "news", response.ID)
+		response.ImageURL = utils.PrependBaseURL(response.ImageURL, config.GetEnv("BASE_URL", ""))
+		prependGalleryBaseURL(response.Images, config.GetEnv("BASE_URL", ""))
+		utils.SetCacheControl(w, r, utils.CacheTTLNewsDetail)
+		utils.RespondSuccessETag(w, r, response, nil)
 		return
 	}
 
 	// Cache miss - get from database
 	db := config.GetDB()
 	var news models.News
-	if err := db.Preload("Author").Where("id = ?", id).First(&news).Error; err != nil {
+	if err := publishedOnlyFor(preloadGallery(preloadAuthor(db, r).Preload("Tags")), r).Where("id = ?", id).First(&news).Error; err != nil {
 		utils.RespondNotFound(w, "News")
 		return
 	}
@@ -238,24 +292,41 @@ func GetNewsByID(w http.ResponseWriter, r *http.Request) {
 		Content:   news.Content,
 		Slug:      news.Slug,
 		Published: news.Published,
+		Featured:  news.Featured,
 		ImageURL:  news.ImageURL,
 		AuthorID:  news.AuthorID,
-		Author: SimplifiedAuthor{
-			ID:   news.Author.ID,
-			Name: news.Author.Name,
-		},
+		Author:    simplifyAuthor(news.Author),
+		Tags:      news.Tags,
+		Images:    news.Images,
+		ViewCount: news.ViewCount,
+		PublishAt: news.PublishAt,
 		CreatedAt: news.CreatedAt,
 		UpdatedAt: news.UpdatedAt,
+
+		CanonicalURL: news.CanonicalURL,
+		PublishedAt:  news.PublishedAt,
 	}
 
+	// Count the view
+	utils.IncrementViewCount(ctx, "news", response.ID)
+
 	// Cache the response
-	_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLNewsDetail)
+	if !admin {
+		_ = utils.CacheSet(ctx, cacheKey, response, utils.CacheTTLNewsDetail)
+	}
+
+	// Admins can ask where the images are stored
+	if includePaths(r) {
+		response.StoredFiles = storedFiles(response.ImageURL, response.Images)
+	}
 
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
 	response.ImageURL = utils.PrependBaseURL(response.ImageURL, baseURL)
+	prependGalleryBaseURL(response.Images, baseURL)
 
-	utils.RespondSuccess(w, http.StatusOK, response, nil)
+	utils.SetCacheControl(w, r, utils.CacheTTLNewsDetail)
+	utils.RespondSuccessETag(w, r, response, nil)
 }
 
 // CreateNews creates a new news article with image upload
@@ -273,14 +344,33 @@ func CreateNews(w http.ResponseWriter, r *http.Request) {
 	content = utils.SanitizeHTML(content)
 	slug := r.FormValue("slug")
 	published := r.FormValue("published") == "true"
+	featured := r.FormValue("featured") == "true"
 
 	// Validate required fields
 	fields := make(map[string]string)
 	if title == "" {
 		fields["title"] = "Title is required"
+	} else if msg := fieldLengthError("title", title); msg != "" {
+		fields["title"] = msg
 	}
 	if content == "" {
 		fields["content"] = "Content is required"
+	} else if msg := fieldLengthError("content", content); msg != "" {
+		fields["content"] = msg
+	}
+	if slug != "" && utils.IsIDLike(slug) {
+		fields["slug"] = "Slug must not look like an ID"
+	} else if msg := fieldLengthError("slug", slug); msg != "" {
+		fields["slug"] = msg
+	}
+	excerpt, manualExcerpt := formValue(r, "excerpt")
+	excerpt = utils.CleanExcerpt(excerpt)
+	if msg := excerptLengthError(excerpt); msg != "" {
+		fields["excerpt"] = msg
+	}
+	canonicalURL := strings.TrimSpace(r.FormValue("canonical_url"))
+	if msg := canonicalURLError(canonicalURL); msg != "" {
+		fields["canonical_url"] = msg
 	}
 	
 	if len(fields) > 0 {
@@ -289,12 +379,53 @@ func CreateNews(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Auto-generate slug from title if not provided
-	if slug == "" {
+	autoSlug := slug == ""
+	if autoSlug {
 		slug = utils.GenerateSlug(title)
+		// Titles without any Latin letters or digits (e.g. CJK) give no slug
+		if slug == "" {
+			slug = "news"
+		}
+	}
+
+	// Use the editor's excerpt verbatim, otherwise generate one from content
+	manualExcerpt = manualExcerpt && excerpt != ""
+	if !manualExcerpt {
+		excerpt = utils.MakeExcerpt(content, 160)
+	}
+
+	// Optional scheduled publish time, moot when published right away
+	publishAt, ok := parsePublishAt(w, r.FormValue("publish_at"))
+	if !ok {
+		return
+	}
+	var publishedAt *time.Time
+	if published {
+		now := time.Now()
+		publishAt, publishedAt = nil, &now
+	}
+
+	// Tags must each give a slug
+	tagNames, ok := parseTags(w, r.FormValue("tags"))
+	if !ok {
+		return
+	}
+
+	// Get author ID from token before anything is stored
+	claims, ok := requireClaims(w, r)
+	if !ok {
+		return
 	}
 
 	// Get the image file (optional)
 	var imageURL string
+	// Remove the uploaded image again on every path that doesn't create the article
+	created := false
+	defer func() {
+		if !created {
+			utils.DeleteImage(imageURL)
+		}
+	}()
 	file, header, err := r.FormFile("image")
 	if err == nil {
 		defer file.Close()
@@ -302,23 +433,35 @@ func CreateNews(w http.ResponseWriter, r *http.Request) {
 		// Validate and save the image
 		imageResult, err := utils.SaveImage(file, header, "news")
 		if err != nil {
-			utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+			respondImageSaveError(w, r, err)
 			return
 		}
 		imageURL = imageResult.URL
 	}
 
-	// Get author ID from token
-	claims, _ := r.Context().Value(middleware.UserContextKey).(*utils.Claims)
+	// Publishing (now or scheduled) may require an image
+	if (published || publishAt != nil) && imageURL == "" && utils.RequireImageToPublish("news") {
+		utils.RespondValidationError(w, map[string]string{
+			"image": "An image is required to publish",
+		})
+		return
+	}
 
-	// Check if slug already exists
+	// A generated slug gets a "-N" suffix when taken; a slug the editor chose
+	// must be free, including among soft-deleted rows that still hold the
+	// unique index
 	db := config.GetDB()
 	var existingNews models.News
-	if err := db.Where("slug = ?", slug).First(&existingNews).Error; err == nil {
-		// Slug already exists
-		if imageURL != "" {
-			utils.DeleteImage(imageURL) // Clean up uploaded image
+	baseSlug := slug
+	if autoSlug {
+		unique, err := utils.GenerateUniqueSlug(db, "news", slug)
+		if err != nil {
+			utils.RespondInternalError(w)
+			return
 		}
+		slug = unique
+	} else if err := db.Unscoped().Where("slug = ?", slug).First(&existingNews).Error; err == nil {
+		// Slug already exists
 		utils.RespondValidationError(w, map[string]string{
 			"slug": "Slug already exists. Please use a different slug.",
 		})
@@ -327,31 +470,69 @@ func CreateNews(w http.ResponseWriter, r *http.Request) {
 
 	// Create news object
 	news := models.News{
-		Title:     title,
-		Content:   content,
-		Excerpt:   utils.MakeExcerpt(content, 160),
-		Slug:      slug,
-		Published: published,
-		ImageURL:  imageURL,
-		AuthorID:  claims.UserID,
-	}
-
-	// Save to database
-	if err := db.Create(&news).Error; err != nil {
-		// If database save fails, delete the uploaded image
-		if imageURL != "" {
-			utils.DeleteImage(imageURL)
+		Title:         title,
+		Content:       content,
+		Excerpt:       excerpt,
+		ExcerptManual: manualExcerpt,
+		Slug:          slug,
+		Published:     published,
+		Featured:      featured,
+		PublishAt:     publishAt,
+		PublishedAt:   publishedAt,
+		ImageURL:      imageURL,
+		CanonicalURL:  canonicalURL,
+		AuthorID:      claims.UserID,
+	}
+
+	// Save to database together with its tags. A concurrent create can take
+	// the slug after the check above: a generated slug then moves on to the
+	// next free one, a slug the editor chose is a conflict.
+	for attempt := 1; ; attempt++ {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			tags, err := upsertTags(tx, tagNames)
+			if err != nil {
+				return err
+			}
+			news.Tags = tags
+			err = tx.Create(&news).Error
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errSlugTaken
+			}
+			return err
+		})
+		if !errors.Is(err, errSlugTaken) || !autoSlug || attempt == maxCreateAttempts {
+			break
+		}
+		if news.Slug, err = utils.GenerateUniqueSlug(db, "news", baseSlug); err != nil {
+			break
 		}
+	}
+	if errors.Is(err, errSlugTaken) {
+		respondSlugTaken(w)
+		return
+	}
+	if err != nil {
 		utils.RespondInternalError(w)
 		return
 	}
+	created = true
 
-	// Invalidate all news list caches
+	audit(r, "news.create", "news", news.ID, "slug="+news.Slug)
+	if news.PublishAt != nil {
+		utils.WakeScheduledPublisher()
+	}
+	if news.Published {
+		utils.NotifyPublished("news", news.ID, news.Slug, news.Title)
+	}
+
+	// Invalidate all news list caches (and the unified posts feed)
 	ctx := r.Context()
-	_ = utils.CacheDeletePattern(ctx, "news:list:*")
+	utils.InvalidateContentCaches(ctx, "news", nil, nil)
 
 	utils.RespondSuccess(w, http.StatusCreated, map[string]interface{}{
-		"id": news.ID,
+		"id":        news.ID,
+		"slug":      news.Slug,
+		"published": news.Published,
 	}, nil)
 }
 
@@ -364,7 +545,7 @@ func UpdateNews(w http.ResponseWriter, r *http.Request) {
 
 	db := config.GetDB()
 	var news models.News
-	if err := db.Preload("Author").First(&news, "id = ?", id).Error; err != nil {
+	if err := preloadAuthor(db, r).First(&news, "id = ?", id).Error; err != nil {
 		utils.RespondNotFound(w, "News")
 		return
 	}
@@ -377,25 +558,79 @@ func UpdateNews(w http.ResponseWriter, r *http.Request) {
 
 	// Track what was updated for response
 	updated := make(map[string]bool)
+	// Keep the old content for orphan image cleanup after the save
+	oldContent := news.Content
+	// Keep the old slug and state for slug history and cache invalidation
+	oldSlug := news.Slug
+	wasPublished := news.Published
 
 	// Update text fields if provided (only fields that are sent)
 	if title := r.FormValue("title"); title != "" {
+		if msg := fieldLengthError("title", title); msg != "" {
+			utils.RespondValidationError(w, map[string]string{
+				"title": msg,
+			})
+			return
+		}
 		news.Title = title
 		updated["title"] = true
 	}
 	if content := r.FormValue("content"); content != "" {
-		// Save old content before overwriting (for orphan image cleanup)
-		oldContent := news.Content
 		// Sanitize HTML content to prevent XSS
 		news.Content = utils.SanitizeHTML(content)
-		// Regenerate excerpt from sanitized content
-		news.Excerpt = utils.MakeExcerpt(news.Content, 160)
+		if msg := fieldLengthError("content", news.Content); msg != "" {
+			utils.RespondValidationError(w, map[string]string{
+				"content": msg,
+			})
+			return
+		}
 		updated["content"] = true
+		// Regenerate excerpt from sanitized content unless an editor wrote it
+		if !news.ExcerptManual {
+			news.Excerpt = utils.MakeExcerpt(news.Content, 160)
+			updated["excerpt"] = true
+		}
+	}
+	if excerpt, ok := formValue(r, "excerpt"); ok {
+		excerpt = utils.CleanExcerpt(excerpt)
+		if msg := excerptLengthError(excerpt); msg != "" {
+			utils.RespondValidationError(w, map[string]string{"excerpt": msg})
+			return
+		}
+		// An empty excerpt switches back to the auto-generated one
+		news.ExcerptManual = excerpt != ""
+		if news.ExcerptManual {
+			news.Excerpt = excerpt
+		} else {
+			news.Excerpt = utils.MakeExcerpt(news.Content, 160)
+		}
 		updated["excerpt"] = true
-		// Delete inline images that were removed from the content
-		utils.DeleteOrphanContentImages(oldContent, news.Content)
+	}
+	if canonicalURL, ok := formValue(r, "canonical_url"); ok {
+		// An empty value clears it, so the slug-based URL is canonical again
+		canonicalURL = strings.TrimSpace(canonicalURL)
+		if msg := canonicalURLError(canonicalURL); msg != "" {
+			utils.RespondValidationError(w, map[string]string{
+				"canonical_url": msg,
+			})
+			return
+		}
+		news.CanonicalURL = canonicalURL
+		updated["canonical_url"] = true
 	}
 	if slug := r.FormValue("slug"); slug != "" {
+		if utils.IsIDLike(slug) {
+			utils.RespondValidationError(w, map[string]string{
+				"slug": "Slug must not look like an ID",
+			})
+			return
+		}
+		if msg := fieldLengthError("slug", slug); msg != "" {
+			utils.RespondValidationError(w, map[string]string{
+				"slug": msg,
+			})
+			return
+		}
 		news.Slug = slug
 		updated["slug"] = true
 	}
@@ -403,22 +638,58 @@ func UpdateNews(w http.ResponseWriter, r *http.Request) {
 		news.Published = published == "true"
 		updated["published"] = true
 	}
+	if featured := r.FormValue("featured"); featured != "" {
+		news.Featured = featured == "true"
+		updated["featured"] = true
+	}
+	if value, ok := formValue(r, "publish_at"); ok {
+		// An empty value cancels the schedule
+		publishAt, valid := parsePublishAt(w, value)
+		if !valid {
+			return
+		}
+		news.PublishAt = publishAt
+		updated["publish_at"] = true
+	}
+	// Record when a draft goes live by hand; a pending schedule is moot now.
+	// Taking an item offline drops a schedule this request didn't set, or the
+	// scheduled publisher would bring it straight back.
+	if news.Published && !wasPublished {
+		now := time.Now()
+		news.PublishedAt = &now
+		news.PublishAt = nil
+	} else if !news.Published && wasPublished && !updated["publish_at"] {
+		news.PublishAt = nil
+	}
+
+	// A draft's slug may follow its new title; published slugs never change implicitly
+	if updated["title"] && !updated["slug"] && !wasPublished && autoSlugOnTitle(r) {
+		if base := utils.GenerateSlug(news.Title); base != "" {
+			slug, err := utils.UniqueSlugExcluding(db, "news", base, news.ID)
+			if err != nil {
+				utils.RespondInternalError(w)
+				return
+			}
+			if slug != news.Slug {
+				news.Slug = slug
+				updated["slug"] = true
+			}
+		}
+	}
 
 	// Handle image operations
 	deleteImage := r.FormValue("delete_image") == "true"
 	file, header, err := r.FormFile("image")
 	hasNewImage := err == nil
 
+	// The replaced image is only deleted once the database save succeeds,
+	// and a freshly uploaded image is removed again if the save fails
+	var staleImageURL, newImageURL string
 	if deleteImage {
 		// Delete image without uploading new one
-		oldImageURL := news.ImageURL
+		staleImageURL = news.ImageURL
 		news.ImageURL = ""
 		updated["image_deleted"] = true
-
-		// Delete old image file
-		if oldImageURL != "" {
-			utils.DeleteImage(oldImageURL)
-		}
 	} else if hasNewImage {
 		// New image uploaded
 		defer file.Close()
@@ -426,37 +697,60 @@ func UpdateNews(w http.ResponseWriter, r *http.Request) {
 		// Validate and save the new image
 		imageResult, err := utils.SaveImage(file, header, "news")
 		if err != nil {
-			utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+			respondImageSaveError(w, r, err)
 			return
 		}
 
 		// Store old image URL for deletion
-		oldImageURL := news.ImageURL
+		staleImageURL = news.ImageURL
+		newImageURL = imageResult.URL
 		news.ImageURL = imageResult.URL
 		updated["image_updated"] = true
+	}
 
-		// Delete old image after new one is saved
-		if oldImageURL != "" {
-			utils.DeleteImage(oldImageURL)
-		}
+	// Publishing (now or scheduled) or removing the image of published
+	// content may require an image
+	publishing := news.Published || news.PublishAt != nil
+	if publishing && news.ImageURL == "" && (updated["published"] || updated["publish_at"] || updated["image_deleted"]) && utils.RequireImageToPublish("news") {
+		utils.RespondValidationError(w, map[string]string{
+			"image": "An image is required to publish",
+		})
+		return
 	}
 
-	// Save to database if any field was updated
-	oldSlug := news.Slug
+	// Save to database if any field was updated, recording a slug change
 	if len(updated) > 0 {
-		if err := db.Save(&news).Error; err != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Omit("view_count").Save(&news).Error; err != nil {
+				return err
+			}
+			return recordSlugChange(tx, "news", news.ID, oldSlug, news.Slug)
+		})
+		if err != nil {
+			// Don't leave the newly uploaded image behind as an orphan
+			utils.DeleteImage(newImageURL)
 			utils.RespondInternalError(w)
 			return
 		}
 
+		// Delete the replaced image now that the new state is persisted
+		utils.DeleteImage(staleImageURL)
+		// Delete inline images that were removed from the content
+		if updated["content"] {
+			utils.DeleteOrphanContentImages(oldContent, news.Content)
+		}
+
+		audit(r, "news.update", "news", news.ID, "fields="+updatedFields(updated))
+		if updated["publish_at"] {
+			utils.WakeScheduledPublisher()
+		}
+		if news.Published && !wasPublished {
+			utils.NotifyPublished("news", news.ID, news.Slug, news.Title)
+		}
+
 		// Invalidate caches
 		ctx := r.Context()
-		_ = utils.CacheDeletePattern(ctx, "news:list:*")
-		_ = utils.CacheDelete(ctx, utils.BuildCacheKey("news", "id", id))
-		_ = utils.CacheDelete(ctx, utils.BuildCacheKey("news", "slug", oldSlug))
-		if updated["slug"] && news.Slug != oldSlug {
-			_ = utils.CacheDelete(ctx, utils.BuildCacheKey("news", "slug", news.Slug))
-		}
+		utils.InvalidateContentCaches(ctx, "news", []string{id}, []string{oldSlug, news.Slug})
 	}
 
 	// Prepare response with updated news data
@@ -466,19 +760,26 @@ func UpdateNews(w http.ResponseWriter, r *http.Request) {
 		Content:   news.Content,
 		Slug:      news.Slug,
 		Published: news.Published,
+		Featured:  news.Featured,
 		ImageURL:  news.ImageURL,
 		AuthorID:  news.AuthorID,
-		Author: SimplifiedAuthor{
-			ID:   news.Author.ID,
-			Name: news.Author.Name,
-		},
+		Author:    simplifyAuthor(news.Author),
 		CreatedAt: news.CreatedAt,
 		UpdatedAt: news.UpdatedAt,
+
+		CanonicalURL: news.CanonicalURL,
+		PublishedAt:  news.PublishedAt,
+	}
+
+	// Admins can ask where the images are stored
+	if includePaths(r) {
+		response.StoredFiles = storedFiles(response.ImageURL, response.Images)
 	}
 
 	// Add BASE_URL to response
 	baseURL := config.GetEnv("BASE_URL", "")
 	response.ImageURL = utils.PrependBaseURL(response.ImageURL, baseURL)
+	prependGalleryBaseURL(response.Images, baseURL)
 
 	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
 		"message": "News updated successfully",
@@ -487,14 +788,14 @@ func UpdateNews(w http.ResponseWriter, r *http.Request) {
 	}, nil)
 }
 
-// DeleteNews soft deletes a news article and removes its image
+// DeleteNews soft deletes a news article, keeping its images until it is purged
 func DeleteNews(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
 	db := config.GetDB()
 	
-	// Get the news first to retrieve image URL
+	// Get the news first to make sure it exists
 	var news models.News
 	if err := db.First(&news, "id = ?", id).Error; err != nil {
 		utils.RespondNotFound(w, "News")
@@ -507,17 +808,14 @@ func DeleteNews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Delete the thumbnail image file
-	utils.DeleteImage(news.ImageURL)
+	// The image files and gallery are kept so the item can be restored from
+	// the trash; purging it removes them
 
-	// Delete all inline images embedded in the content (uploaded via rich text editor)
-	utils.DeleteContentImages(news.Content)
+	audit(r, "news.delete", "news", news.ID, "slug="+news.Slug)
 
 	// Invalidate caches
 	ctx := r.Context()
-	_ = utils.CacheDeletePattern(ctx, "news:list:*")
-	_ = utils.CacheDelete(ctx, utils.BuildCacheKey("news", "id", id))
-	_ = utils.CacheDelete(ctx, utils.BuildCacheKey("news", "slug", news.Slug))
+	utils.InvalidateContentCaches(ctx, "news", []string{id}, []string{news.Slug})
 
 	utils.RespondSuccess(w, http.StatusOK, map[string]string{
 		"message": "News deleted successfully",
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sentul-golf-be/models"
+
+	"github.com/gorilla/mux"
+)
+
+func TestNewsMutationsInvalidateCaches(t *testing.T) {
+	listsID := func(body []byte, id string) bool { return bytes.Contains(body, []byte(id)) }
+
+	views := []struct {
+		name    string
+		handler http.HandlerFunc
+		target  string
+		admin   bool
+		shows   func(body []byte, id string) bool // Reports whether the response lists the article
+	}{
+		{"news list", GetNews, "/api/news", true, listsID},
+		{"posts feed", GetPosts, "/api/posts", false, listsID},
+		{"home", GetHome, "/api/home", false, listsID},
+		{"navigation", GetNavigation, "/api/navigation", false, func(body []byte, _ string) bool {
+			var nav struct {
+				Data NavigationResponse `json:"data"`
+			}
+			return json.Unmarshal(body, &nav) == nil && nav.Data.NewsCount > 0
+		}},
+		{"admin stats", GetDashboardStats, "/api/admin/stats", true, listsID},
+	}
+
+	for _, view := range views {
+		t.Run(view.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			cache := setupTestRedis(t)
+			admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+			news := models.News{Title: "Opening Day", Content: "<p>x</p>", Slug: "opening-day", AuthorID: admin.ID, Published: true}
+			if err := db.Create(&news).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			get := func() []byte {
+				r := httptest.NewRequest(http.MethodGet, view.target, nil)
+				if view.admin {
+					r = withClaims(r, admin.ID, models.RoleAdmin)
+				}
+				w := httptest.NewRecorder()
+				view.handler(w, r)
+				if w.Code != http.StatusOK {
+					t.Fatalf("GET %s: status = %d, want 200 (body %s)", view.target, w.Code, w.Body.String())
+				}
+				return w.Body.Bytes()
+			}
+
+			// Warm the cache
+			if body := get(); !view.shows(body, news.ID) {
+				t.Fatalf("article missing before the delete: %s", body)
+			}
+			if len(cache.Keys()) == 0 {
+				t.Fatal("response was not cached")
+			}
+
+			r := httptest.NewRequest(http.MethodDelete, "/api/news/"+news.ID, nil)
+			r = withClaims(mux.SetURLVars(r, map[string]string{"id": news.ID}), admin.ID, models.RoleAdmin)
+			w := httptest.NewRecorder()
+			DeleteNews(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("delete: status = %d, want 200 (body %s)", w.Code, w.Body.String())
+			}
+
+			if body := get(); view.shows(body, news.ID) {
+				t.Errorf("deleted article still listed: %s", body)
+			}
+		})
+	}
+}
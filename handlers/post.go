@@ -18,6 +18,7 @@ type PostResponse struct {
 	Excerpt    string           `json:"excerpt"` // Plain text excerpt instead of full content
 	Slug       string           `json:"slug"`
 	Published  bool             `json:"published"`
+	Featured   bool             `json:"featured"`
 	ImageURL   string           `json:"image_url"`
 	AuthorID   string           `json:"author_id"`
 	Author     SimplifiedAuthor `json:"author"`
@@ -27,217 +28,355 @@ type PostResponse struct {
 	UpdatedAt  time.Time        `json:"updated_at"`
 }
 
+// postSortOrders maps the allowed sort query values to SQL ORDER BY clauses.
+// Featured posts always come first and each ends with the id so ties keep a
+// stable order across pages.
+var postSortOrders = map[string]string{
+	"newest": "featured DESC, created_at DESC, id DESC",
+	"oldest": "featured DESC, created_at ASC, id ASC",
+	"title":  "featured DESC, title ASC, id ASC",
+}
+
+// postsDefaultLimit returns the configured default page size for GetPosts
+func postsDefaultLimit() int {
+	if l, err := strconv.Atoi(config.GetEnv("POSTS_DEFAULT_LIMIT", "")); err == nil && l > 0 && l <= utils.MaxPageLimit {
+		return l
+	}
+	return utils.DefaultPageLimit
+}
+
+// postsDefaultSort returns the configured default sort for GetPosts
+func postsDefaultSort() string {
+	if s := config.GetEnv("POSTS_DEFAULT_SORT", ""); postSortOrders[s] != "" {
+		return s
+	}
+	return "newest"
+}
+
+// postsRequireType reports whether GetPosts refuses the combined news and
+// events feed, set with POSTS_REQUIRE_TYPE=true
+func postsRequireType() bool {
+	return config.GetEnv("POSTS_REQUIRE_TYPE", "false") == "true"
+}
+
+// newsToPost converts a news article to the unified post shape
+func newsToPost(n models.News) PostResponse {
+	return PostResponse{
+		ID:        n.ID,
+		Type:      "NEWS",
+		Title:     n.Title,
+		Excerpt:   n.Excerpt,
+		Slug:      n.Slug,
+		Published: n.Published,
+		Featured:  n.Featured,
+		ImageURL:  n.ImageURL,
+		AuthorID:  n.AuthorID,
+		Author:    simplifyAuthor(n.Author),
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
+
+// eventToPost converts an event to the unified post shape
+func eventToPost(e models.Event) PostResponse {
+	return PostResponse{
+		ID:        e.ID,
+		Type:      "EVENT",
+		Title:     e.Title,
+		Excerpt:   e.Excerpt,
+		Slug:      e.Slug,
+		Published: e.Published,
+		Featured:  e.Featured,
+		ImageURL:  e.ImageURL,
+		AuthorID:  e.AuthorID,
+		Author:    simplifyAuthor(e.Author),
+		EventStart: e.EventStart,
+		EventEnd:   e.EventEnd,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
+
 // GetPosts retrieves news and/or events based on optional type query parameter
-// If no type specified, returns both news and events sorted by newest first
+// If no type specified, returns both news and events sorted by newest first,
+// unless POSTS_REQUIRE_TYPE makes the type mandatory
 // If type=news, returns only news
 // If type=event, returns only events
+// Optional sort: newest (default), oldest, title
+// Optional search (or q): keyword match on title and excerpt
+// Optional tag (repeatable) with tag_mode=any (default) or all: only posts
+// carrying any or all of the tags with these slugs
+// Optional status: upcoming or past, only events
+// Optional featured=true: only pinned posts
 func GetPosts(w http.ResponseWriter, r *http.Request) {
-	db := config.GetDB()
-	baseURL := config.GetEnv("BASE_URL", "")
-	
+	ctx := r.Context()
+
 	// Get type parameter (optional)
 	typeParam := r.URL.Query().Get("type")
-	
+
 	// Validate type if provided
 	if typeParam != "" && typeParam != "news" && typeParam != "event" {
 		utils.RespondError(w, http.StatusBadRequest, "INVALID_TYPE", "Type must be 'news' or 'event'", nil)
 		return
 	}
-	
+	// The combined feed can be turned off
+	if typeParam == "" && postsRequireType() {
+		utils.RespondValidationError(w, map[string]string{
+			"type": "Type is required and must be 'news' or 'event'",
+		})
+		return
+	}
+
+	// Get sort parameter (optional)
+	sortParam := r.URL.Query().Get("sort")
+	if sortParam == "" {
+		sortParam = postsDefaultSort()
+	}
+	orderClause, ok := postSortOrders[sortParam]
+	if !ok {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_SORT", "Sort must be 'newest', 'oldest' or 'title'", nil)
+		return
+	}
+
 	// Get pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-	
-	page := 1
-	limit := 10 // Default 10 items per page
-	
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
+	page, limit, offset := utils.ParsePagination(r, postsDefaultLimit())
+
+	// Optional keyword search on title and excerpt, as search or q
+	search := searchParam(r)
+	if search == "" {
+		search = normalizeSearch(r.URL.Query().Get("q"))
+	}
+	// Optional tag filter
+	tags, valid := tagFilterParam(w, r)
+	if !valid {
+		return
+	}
+
+	// Optional upcoming/past filter; only events have dates, so it limits the
+	// feed to events
+	status, valid := eventStatusParam(w, r)
+	if !valid {
+		return
 	}
-	
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
+	if status != "" {
+		if typeParam == "news" {
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_STATUS", "Status can only be used with events", nil)
+			return
 		}
+		typeParam = "event"
+	}
+	now := time.Now()
+	hidePast := hidePastEvents(r)
+	// Optional pinned-only filter
+	featured := featuredOnly(r)
+
+	// Try cache first
+	cacheType := typeParam
+	if cacheType == "" {
+		cacheType = "all"
+	}
+	cacheKey := utils.BuildCacheKey("post", "list", "type", cacheType, "sort", sortParam, "page", page, "limit", limit)
+	cacheKey = tags.cacheKey(cacheKey)
+	if status != "" {
+		cacheKey = utils.BuildCacheKey(cacheKey, "status", status)
+	}
+	if hidePast && typeParam != "news" {
+		cacheKey = utils.BuildCacheKey(cacheKey, "hide_past")
+	}
+	if featured {
+		cacheKey = utils.BuildCacheKey(cacheKey, "featured")
+	}
+	if search != "" {
+		cacheKey = utils.BuildCacheKey(cacheKey, "search", search)
+	}
+	type CachedPostsResponse struct {
+		Posts []PostResponse `json:"posts"`
+		Meta  *utils.Meta    `json:"meta"`
+	}
+	var cached CachedPostsResponse
+	if err := cacheGet(ctx, r, cacheKey, &cached); err == nil {
+		utils.SetCacheControl(w, r, utils.CacheTTLPostsList)
+		respondPosts(w, r, cached.Posts, cached.Meta)
+		return
 	}
-	
-	offset := (page - 1) * limit
-	
+
+	// Cache miss - get from database
+	db := config.GetDB()
 	var posts []PostResponse
 	var total int64
-	
+
 	if typeParam == "news" {
 		// Get only news (published)
 		var news []models.News
-		newsQuery := db.Preload("Author").Where("published = ?", true)
-		
+		newsQuery := applySearch(db.Preload("Author").Where("published = ?", true), search)
+		newsQuery = applyTagFilter(newsQuery, newsTags, tags)
+		newsQuery = applyFeatured(newsQuery, featured)
+
 		// Count total
-		db.Model(&models.News{}).Where("published = ?", true).Count(&total)
-		
+		countQuery := applySearch(db.Model(&models.News{}).Where("published = ?", true), search)
+		countQuery = applyTagFilter(countQuery, newsTags, tags)
+		applyFeatured(countQuery, featured).Count(&total)
+
 		// Get paginated results
-		if err := newsQuery.Order("created_at DESC").Limit(limit).Offset(offset).Find(&news).Error; err != nil {
+		if err := newsQuery.Order(orderClause).Limit(limit).Offset(offset).Find(&news).Error; err != nil {
 			utils.RespondInternalError(w)
 			return
 		}
-		
+
 		// Transform to posts
 		posts = make([]PostResponse, len(news))
 		for i, n := range news {
-			posts[i] = PostResponse{
-				ID:        n.ID,
-				Type:      "NEWS",
-				Title:     n.Title,
-				Excerpt:   n.Excerpt,
-				Slug:      n.Slug,
-				Published: n.Published,
-				ImageURL:  utils.PrependBaseURL(n.ImageURL, baseURL),
-				AuthorID:  n.AuthorID,
-				Author: SimplifiedAuthor{
-					ID:   n.Author.ID,
-					Name: n.Author.Name,
-				},
-				CreatedAt: n.CreatedAt,
-				UpdatedAt: n.UpdatedAt,
-			}
+			posts[i] = newsToPost(n)
 		}
 	} else if typeParam == "event" {
 		// Get only events (published)
 		var events []models.Event
-		eventsQuery := db.Preload("Author").Where("published = ?", true)
-		
+		eventsQuery := applySearch(db.Preload("Author").Where("published = ?", true), search)
+		eventsQuery = applyTagFilter(eventsQuery, eventTags, tags)
+		eventsQuery = applyEventStatus(eventsQuery, status, now)
+		eventsQuery = applyHidePastEvents(eventsQuery, hidePast, now)
+		eventsQuery = applyFeatured(eventsQuery, featured)
+
 		// Count total
-		db.Model(&models.Event{}).Where("published = ?", true).Count(&total)
-		
+		countQuery := applySearch(db.Model(&models.Event{}).Where("published = ?", true), search)
+		countQuery = applyTagFilter(countQuery, eventTags, tags)
+		countQuery = applyEventStatus(countQuery, status, now)
+		countQuery = applyHidePastEvents(countQuery, hidePast, now)
+		applyFeatured(countQuery, featured).Count(&total)
+
 		// Get paginated results
-		if err := eventsQuery.Order("created_at DESC").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+		if err := eventsQuery.Order(orderClause).Limit(limit).Offset(offset).Find(&events).Error; err != nil {
 			utils.RespondInternalError(w)
 			return
 		}
-		
+
 		// Transform to posts
 		posts = make([]PostResponse, len(events))
 		for i, e := range events {
-			posts[i] = PostResponse{
-				ID:        e.ID,
-				Type:      "EVENT",
-				Title:     e.Title,
-				Excerpt:   e.Excerpt,
-				Slug:      e.Slug,
-				Published: e.Published,
-				ImageURL:  utils.PrependBaseURL(e.ImageURL, baseURL),
-				AuthorID:  e.AuthorID,
-				Author: SimplifiedAuthor{
-					ID:   e.Author.ID,
-					Name: e.Author.Name,
-				},
-				EventStart: e.EventStart,
-				EventEnd:   e.EventEnd,
-				CreatedAt:  e.CreatedAt,
-				UpdatedAt:  e.UpdatedAt,
-			}
+			posts[i] = eventToPost(e)
 		}
 	} else {
-		// Get both news and events (published), sorted by newest
-		var news []models.News
-		var events []models.Event
-		
-		// Get all news
-		if err := db.Preload("Author").Where("published = ?", true).Order("created_at DESC").Find(&news).Error; err != nil {
-			utils.RespondInternalError(w)
-			return
+		// Page through news and events together in the database: the UNION
+		// only selects the sort columns, then the page's rows are loaded by ID
+		var keys []struct {
+			ID   string
+			Type string
+		}
+		where := "published = ? AND deleted_at IS NULL"
+		whereArgs := []interface{}{true}
+		if featured {
+			where += " AND featured = ?"
+			whereArgs = append(whereArgs, true)
+		}
+		if search != "" {
+			pattern := utils.ContainsPattern(search)
+			where += " AND " + searchCondition
+			whereArgs = append(whereArgs, pattern, pattern)
 		}
-		
-		// Get all events
-		if err := db.Preload("Author").Where("published = ?", true).Order("created_at DESC").Find(&events).Error; err != nil {
+		newsWhere, eventsWhere := where, where
+		if tags.active() {
+			// Both conditions take the same arguments
+			newsCondition, tagArgs := tags.condition(newsTags)
+			eventsCondition, _ := tags.condition(eventTags)
+			newsWhere += " AND " + newsCondition
+			eventsWhere += " AND " + eventsCondition
+			whereArgs = append(whereArgs, tagArgs...)
+		}
+		newsArgs := whereArgs
+		eventsArgs := append([]interface{}{}, whereArgs...)
+		if hidePast {
+			eventsWhere += " AND " + notEndedCondition
+			eventsArgs = append(eventsArgs, now)
+		}
+		unionQuery := `SELECT id, 'NEWS' AS type, title, featured, created_at FROM news WHERE ` + newsWhere + `
+			UNION ALL
+			SELECT id, 'EVENT' AS type, title, featured, created_at FROM events WHERE ` + eventsWhere + `
+			ORDER BY ` + orderClause + ` LIMIT ? OFFSET ?`
+		args := append(append(append([]interface{}{}, newsArgs...), eventsArgs...), limit, offset)
+		if err := db.Raw(unionQuery, args...).Scan(&keys).Error; err != nil {
 			utils.RespondInternalError(w)
 			return
 		}
-		
-		// Combine into posts
-		var allPosts []PostResponse
-		
-		// Add news
-		for _, n := range news {
-			allPosts = append(allPosts, PostResponse{
-				ID:        n.ID,
-				Type:      "NEWS",
-				Title:     n.Title,
-				Excerpt:   n.Excerpt,
-				Slug:      n.Slug,
-				Published: n.Published,
-				ImageURL:  utils.PrependBaseURL(n.ImageURL, baseURL),
-				AuthorID:  n.AuthorID,
-				Author: SimplifiedAuthor{
-					ID:   n.Author.ID,
-					Name: n.Author.Name,
-				},
-				CreatedAt: n.CreatedAt,
-				UpdatedAt: n.UpdatedAt,
-			})
-		}
-		
-		// Add events
-		for _, e := range events {
-			allPosts = append(allPosts, PostResponse{
-				ID:         e.ID,
-				Type:       "EVENT",
-				Title:      e.Title,
-				Excerpt:    e.Excerpt,
-				Slug:       e.Slug,
-				Published:  e.Published,
-				ImageURL:   utils.PrependBaseURL(e.ImageURL, baseURL),
-				AuthorID:   e.AuthorID,
-				Author: SimplifiedAuthor{
-					ID:   e.Author.ID,
-					Name: e.Author.Name,
-				},
-				EventStart: e.EventStart,
-				EventEnd:   e.EventEnd,
-				CreatedAt:  e.CreatedAt,
-				UpdatedAt:  e.UpdatedAt,
-			})
+
+		// Count total
+		var newsTotal, eventsTotal int64
+		newsCount := applySearch(db.Model(&models.News{}).Where("published = ?", true), search)
+		newsCount = applyTagFilter(newsCount, newsTags, tags)
+		applyFeatured(newsCount, featured).Count(&newsTotal)
+		eventsCount := applySearch(db.Model(&models.Event{}).Where("published = ?", true), search)
+		eventsCount = applyTagFilter(eventsCount, eventTags, tags)
+		eventsCount = applyHidePastEvents(eventsCount, hidePast, now)
+		applyFeatured(eventsCount, featured).Count(&eventsTotal)
+		total = newsTotal + eventsTotal
+
+		// Load the rows of this page
+		var newsIDs, eventIDs []string
+		for _, key := range keys {
+			if key.Type == "NEWS" {
+				newsIDs = append(newsIDs, key.ID)
+			} else {
+				eventIDs = append(eventIDs, key.ID)
+			}
 		}
-		
-		// Sort by created_at DESC (newest first)
-		for i := 0; i < len(allPosts)-1; i++ {
-			for j := i + 1; j < len(allPosts); j++ {
-				if allPosts[i].CreatedAt.Before(allPosts[j].CreatedAt) {
-					allPosts[i], allPosts[j] = allPosts[j], allPosts[i]
-				}
+
+		newsByID := make(map[string]models.News, len(newsIDs))
+		if len(newsIDs) > 0 {
+			var news []models.News
+			if err := db.Preload("Author").Where("id IN ?", newsIDs).Find(&news).Error; err != nil {
+				utils.RespondInternalError(w)
+				return
+			}
+			for _, n := range news {
+				newsByID[n.ID] = n
 			}
 		}
-		
-		// Calculate total
-		total = int64(len(allPosts))
-		
-		// Apply pagination
-		start := offset
-		end := offset + limit
-		
-		if start > len(allPosts) {
-			start = len(allPosts)
+
+		eventsByID := make(map[string]models.Event, len(eventIDs))
+		if len(eventIDs) > 0 {
+			var events []models.Event
+			if err := db.Preload("Author").Where("id IN ?", eventIDs).Find(&events).Error; err != nil {
+				utils.RespondInternalError(w)
+				return
+			}
+			for _, e := range events {
+				eventsByID[e.ID] = e
+			}
 		}
-		if end > len(allPosts) {
-			end = len(allPosts)
+
+		// Keep the order from the UNION query
+		posts = make([]PostResponse, 0, len(keys))
+		for _, key := range keys {
+			if key.Type == "NEWS" {
+				if n, ok := newsByID[key.ID]; ok {
+					posts = append(posts, newsToPost(n))
+				}
+			} else if e, ok := eventsByID[key.ID]; ok {
+				posts = append(posts, eventToPost(e))
+			}
 		}
-		
-		posts = allPosts[start:end]
-	}
-	
-	// Calculate total pages
-	totalPages := int(total) / limit
-	if int(total)%limit != 0 {
-		totalPages++
-	}
-	
-	meta := &utils.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      int(total),
-		TotalPages: totalPages,
-	}
-	
-	utils.RespondSuccess(w, http.StatusOK, posts, meta)
+	}
+
+	meta := utils.NewMeta(page, limit, total)
+
+	// Cache the response (without BASE_URL prepended); empty search results
+	// are not cached so misses don't linger
+	if search == "" || len(posts) > 0 {
+		_ = utils.CacheSet(ctx, cacheKey, CachedPostsResponse{
+			Posts: posts,
+			Meta:  meta,
+		}, utils.CacheTTLPostsList)
+	}
+
+	utils.SetCacheControl(w, r, utils.CacheTTLPostsList)
+	respondPosts(w, r, posts, meta)
+}
+
+// respondPosts adds BASE_URL to image URLs and writes the posts response
+func respondPosts(w http.ResponseWriter, r *http.Request, posts []PostResponse, meta *utils.Meta) {
+	baseURL := config.GetEnv("BASE_URL", "")
+	for i := range posts {
+		posts[i].ImageURL = utils.PrependBaseURL(posts[i].ImageURL, baseURL)
+	}
+
+	utils.RespondList(w, r, "", posts, meta)
 }
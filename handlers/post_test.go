@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"gorm.io/gorm"
+)
+
+// seedPosts inserts published news and events a minute apart, oldest first,
+// plus an unpublished draft that must never show up in the feed
+func seedPosts(t *testing.T, db *gorm.DB, authorID string) {
+	t.Helper()
+
+	created := time.Now().Add(-time.Hour)
+	next := func() time.Time {
+		created = created.Add(time.Minute)
+		return created
+	}
+	rows := []interface{}{
+		&models.News{Title: "Club Championship", Slug: "club-championship", Published: true, CreatedAt: next()},
+		&models.News{Title: "Course Maintenance", Slug: "course-maintenance", Published: true, CreatedAt: next()},
+		&models.Event{Title: "Championship Dinner", Slug: "championship-dinner", Published: true, CreatedAt: next()},
+		&models.News{Title: "Junior Clinic", Slug: "junior-clinic", Published: true, CreatedAt: next()},
+		&models.Event{Title: "Twilight Golf", Slug: "twilight-golf", Published: true, CreatedAt: next()},
+		&models.News{Title: "Championship Draft", Slug: "championship-draft", CreatedAt: next()},
+	}
+	for _, row := range rows {
+		switch row := row.(type) {
+		case *models.News:
+			row.Content, row.AuthorID = "x", authorID
+		case *models.Event:
+			row.Content, row.AuthorID = "x", authorID
+		}
+		if err := db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// postsPage is the decoded body of a GetPosts response
+type postsPage struct {
+	Data []PostResponse `json:"data"`
+	Meta utils.Meta     `json:"meta"`
+}
+
+// getPosts calls GetPosts with query and decodes the response
+func getPosts(t *testing.T, query string) postsPage {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	GetPosts(w, httptest.NewRequest(http.MethodGet, "/api/posts?"+query, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /api/posts?%s: status = %d, want %d (body %s)", query, w.Code, http.StatusOK, w.Body.String())
+	}
+	var page postsPage
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatal(err)
+	}
+	return page
+}
+
+// postTitles returns the titles of posts in order
+func postTitles(posts []PostResponse) []string {
+	titles := make([]string, len(posts))
+	for i, post := range posts {
+		titles[i] = post.Title
+	}
+	return titles
+}
+
+func TestGetPostsCombinedFilters(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		want      []string // Titles of the page, in order
+		wantTotal int
+		wantPages int
+		uncached  bool // Empty search results are not cached
+	}{
+		{"all newest first page", "sort=newest&page=1", []string{"Twilight Golf", "Junior Clinic"}, 5, 3, false},
+		{"all newest last page", "sort=newest&page=3", []string{"Club Championship"}, 5, 3, false},
+		{"all oldest", "sort=oldest&page=1", []string{"Club Championship", "Course Maintenance"}, 5, 3, false},
+		{"all by title second page", "sort=title&page=2", []string{"Course Maintenance", "Junior Clinic"}, 5, 3, false},
+		{"all past the last page", "sort=title&page=4", []string{}, 5, 3, false},
+		{"news newest", "type=news&page=1", []string{"Junior Clinic", "Course Maintenance"}, 3, 2, false},
+		{"news by title second page", "type=news&sort=title&page=2", []string{"Junior Clinic"}, 3, 2, false},
+		{"events oldest", "type=event&sort=oldest&page=1", []string{"Championship Dinner", "Twilight Golf"}, 2, 1, false},
+		{"q across types skips drafts", "q=championship&page=1", []string{"Championship Dinner", "Club Championship"}, 2, 1, false},
+		{"q is case-insensitive", "q=CHAMPIONSHIP&sort=oldest&page=1", []string{"Club Championship", "Championship Dinner"}, 2, 1, false},
+		{"q past the last page", "q=championship&page=2", []string{}, 2, 1, true},
+		{"q with news", "type=news&q=championship&sort=title", []string{"Club Championship"}, 1, 1, false},
+		{"q with events", "type=event&q=championship", []string{"Championship Dinner"}, 1, 1, false},
+		{"search wins over q", "search=clinic&q=championship", []string{"Junior Clinic"}, 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			setupTestRedis(t)
+			admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+			seedPosts(t, db, admin.ID)
+			query := tt.query + "&limit=2"
+
+			first := getPosts(t, query)
+			if got := postTitles(first.Data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("titles = %q, want %q", got, tt.want)
+			}
+			if first.Meta.Total != tt.wantTotal || first.Meta.TotalPages != tt.wantPages || first.Meta.Limit != 2 {
+				t.Errorf("meta = %+v, want total %d over %d pages of 2", first.Meta, tt.wantTotal, tt.wantPages)
+			}
+
+			// A row written behind the handlers' back must not show up until
+			// the cached page expires
+			late := models.News{Title: "Championship Clinic", Slug: "championship-clinic", Content: "x", Published: true, AuthorID: admin.ID}
+			if err := db.Create(&late).Error; err != nil {
+				t.Fatal(err)
+			}
+			second := getPosts(t, query)
+			if cached := reflect.DeepEqual(second, first); cached == tt.uncached {
+				t.Errorf("second read = %+v, want cached %v", second, !tt.uncached)
+			}
+		})
+	}
+}
+
+func TestGetPostsRejectsUnknownParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantCode string
+	}{
+		{"unknown type", "type=hole", "INVALID_TYPE"},
+		{"unknown sort", "sort=popular", "INVALID_SORT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestDB(t)
+			setupTestRedis(t)
+
+			w := httptest.NewRecorder()
+			GetPosts(w, httptest.NewRequest(http.MethodGet, "/api/posts?"+tt.query, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+			if got := errorCode(t, w); got != tt.wantCode {
+				t.Errorf("error code = %q, want %q", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCreatedPostAppearsInFeed(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string // Warmed before the create; matches seeded posts so the page is cached
+	}{
+		{"combined feed", "page=1"},
+		{"typed feed", "type=%s&page=1"},
+		{"sorted by title", "sort=title&page=1"},
+		{"searched", "q=clinic&page=1"},
+	}
+
+	for _, content := range createHandlers {
+		for _, tt := range tests {
+			t.Run(content.name+"/"+tt.name, func(t *testing.T) {
+				db := setupTestDB(t)
+				setupTestUploads(t)
+				setupTestRedis(t)
+				admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+				seedPosts(t, db, admin.ID)
+				query := tt.query
+				if strings.Contains(query, "%s") {
+					query = fmt.Sprintf(query, content.name)
+				}
+
+				for _, post := range getPosts(t, query).Data {
+					if post.Title == "Opening Day Clinic" {
+						t.Fatal("Opening Day Clinic listed before it was created")
+					}
+				}
+
+				fields := map[string]string{"title": "Opening Day Clinic", "content": "<p>Hello</p>", "published": "true"}
+				r := withClaims(newFormRequest(t, http.MethodPost, content.target, fields, testPNG(t)), admin.ID, models.RoleAdmin)
+				w := httptest.NewRecorder()
+				content.handler(w, r)
+				if w.Code != http.StatusCreated {
+					t.Fatalf("create: status = %d, want %d (body %s)", w.Code, http.StatusCreated, w.Body.String())
+				}
+
+				page := getPosts(t, query)
+				for _, post := range page.Data {
+					if post.Title == "Opening Day Clinic" {
+						return
+					}
+				}
+				t.Errorf("Opening Day Clinic missing from the feed after creation: %q", postTitles(page.Data))
+			})
+		}
+	}
+}
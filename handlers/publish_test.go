@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// publishHandlers are the content update handlers sharing the publish logic
+var publishHandlers = []struct {
+	name    string
+	handler http.HandlerFunc
+	target  string
+	model   interface{}
+	create  func(db *gorm.DB, authorID string, publishAt *time.Time) (string, error)
+}{
+	{
+		name:    "news",
+		handler: UpdateNews,
+		target:  "/api/news/",
+		model:   &models.News{},
+		create: func(db *gorm.DB, authorID string, publishAt *time.Time) (string, error) {
+			news := models.News{Title: "Opening Day", Content: "x", Slug: "opening-day", AuthorID: authorID, PublishAt: publishAt}
+			return news.ID, db.Create(&news).Error
+		},
+	},
+	{
+		name:    "event",
+		handler: UpdateEvent,
+		target:  "/api/events/",
+		model:   &models.Event{},
+		create: func(db *gorm.DB, authorID string, publishAt *time.Time) (string, error) {
+			event := models.Event{Title: "Opening Day", Content: "x", Slug: "opening-day", AuthorID: authorID, PublishAt: publishAt}
+			return event.ID, db.Create(&event).Error
+		},
+	},
+}
+
+// publishState is the publishing columns of a news article or event
+type publishState struct {
+	Published   bool
+	PublishAt   *time.Time
+	PublishedAt *time.Time
+}
+
+func TestUnpublishedContentStaysUnpublished(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		publishAt     *time.Time          // Schedule of the draft
+		updates       []map[string]string // Applied in order before the publisher runs
+		wantPublished bool
+		wantSchedule  bool // publish_at is still set
+	}{
+		{
+			name:          "published then unpublished by hand",
+			updates:       []map[string]string{{"published": "true"}, {"published": "false"}},
+			wantPublished: false,
+		},
+		{
+			name:          "scheduled draft published early, then unpublished",
+			publishAt:     &future,
+			updates:       []map[string]string{{"published": "true"}, {"published": "false"}},
+			wantPublished: false,
+		},
+		{
+			name:          "unpublished with a new schedule",
+			updates:       []map[string]string{{"published": "true"}, {"published": "false", "publish_at": future.Format(time.RFC3339)}},
+			wantPublished: false,
+			wantSchedule:  true,
+		},
+		{
+			name:          "due schedule is still published",
+			publishAt:     &past,
+			wantPublished: true,
+		},
+	}
+
+	for _, content := range publishHandlers {
+		for _, tt := range tests {
+			t.Run(content.name+"/"+tt.name, func(t *testing.T) {
+				db := setupTestDB(t)
+				admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+				id, err := content.create(db, admin.ID, tt.publishAt)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, fields := range tt.updates {
+					r := newFormRequest(t, http.MethodPut, content.target+id, fields, nil)
+					r = withClaims(mux.SetURLVars(r, map[string]string{"id": id}), admin.ID, models.RoleAdmin)
+					w := httptest.NewRecorder()
+					content.handler(w, r)
+					if w.Code != http.StatusOK {
+						t.Fatalf("update %v: status = %d, want 200 (body %s)", fields, w.Code, w.Body.String())
+					}
+				}
+
+				if err := utils.PublishScheduledContent(context.Background()); err != nil {
+					t.Fatalf("PublishScheduledContent() error = %v", err)
+				}
+
+				var state publishState
+				if err := db.Model(content.model).Where("id = ?", id).Scan(&state).Error; err != nil {
+					t.Fatal(err)
+				}
+				if state.Published != tt.wantPublished {
+					t.Errorf("published = %v, want %v", state.Published, tt.wantPublished)
+				}
+				if (state.PublishAt != nil) != tt.wantSchedule {
+					t.Errorf("publish_at = %v, want set %v", state.PublishAt, tt.wantSchedule)
+				}
+				if len(tt.updates) > 0 || tt.wantPublished {
+					if state.PublishedAt == nil {
+						t.Error("published_at is not set for an item that went live")
+					}
+				}
+			})
+		}
+	}
+}
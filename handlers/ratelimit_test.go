@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRateLimitedHandlersSetHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		target  string
+		limit   int
+		reset   string // Whole window in seconds
+	}{
+		{"client errors", ReportClientError, "/api/client-errors", 10, "60"},
+		{"forgot password", ForgotPassword, "/api/auth/forgot-password", 5, "900"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestRedis(t)
+
+			for hit := 1; hit <= tt.limit+1; hit++ {
+				// An invalid payload is rejected after the limit is counted
+				r := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader("{"))
+				w := httptest.NewRecorder()
+				tt.handler(w, r)
+
+				exceeded := hit > tt.limit
+				wantStatus := http.StatusBadRequest
+				if exceeded {
+					wantStatus = http.StatusTooManyRequests
+				}
+				if w.Code != wantStatus {
+					t.Fatalf("hit %d: status = %d, want %d", hit, w.Code, wantStatus)
+				}
+
+				remaining := tt.limit - hit
+				if exceeded {
+					remaining = 0
+				}
+				wantHeaders := map[string]string{
+					"RateLimit-Limit":     strconv.Itoa(tt.limit),
+					"RateLimit-Remaining": strconv.Itoa(remaining),
+					"RateLimit-Reset":     tt.reset,
+				}
+				if exceeded {
+					wantHeaders["Retry-After"] = tt.reset
+				}
+				for name, want := range wantHeaders {
+					if got := w.Header().Get(name); got != want {
+						t.Errorf("hit %d: %s = %q, want %q", hit, name, got, want)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+)
+
+// scheduledDefaultRange is the window shown when "to" is not provided
+const scheduledDefaultRange = 30 * 24 * time.Hour
+
+// scheduledMaxRange caps the window a single request may cover
+const scheduledMaxRange = 366 * 24 * time.Hour
+
+// ScheduledItem is a news article or event queued to publish
+type ScheduledItem struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"` // "NEWS" or "EVENT"
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	PublishAt time.Time `json:"publish_at"`
+}
+
+// ScheduledResponse represents the response for the scheduled content endpoint
+type ScheduledResponse struct {
+	From  time.Time       `json:"from"`
+	To    time.Time       `json:"to"`
+	Items []ScheduledItem `json:"items"`
+}
+
+// GetScheduledContent lists the unpublished news and events whose publish_at
+// falls within a window, soonest first, for editorial planning
+// Query params: from (default now) and to (default 30 days after from),
+// RFC3339 or YYYY-MM-DD
+func GetScheduledContent(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	fields := make(map[string]string)
+
+	// Parse date range
+	from := time.Now().UTC()
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := utils.ParseDate(fromStr)
+		if err != nil {
+			fields["from"] = "Invalid date format. Use RFC3339 or YYYY-MM-DD"
+		}
+		from = parsed.UTC()
+	}
+	to := from.Add(scheduledDefaultRange)
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := utils.ParseDate(toStr)
+		if err != nil {
+			fields["to"] = "Invalid date format. Use RFC3339 or YYYY-MM-DD"
+		}
+		to = parsed.UTC()
+	}
+	if len(fields) == 0 && from.After(to) {
+		fields["from"] = "From must be before to"
+	}
+	if len(fields) == 0 && to.Sub(from) > scheduledMaxRange {
+		fields["to"] = "Date range must not exceed 366 days"
+	}
+
+	if len(fields) > 0 {
+		utils.RespondValidationError(w, fields)
+		return
+	}
+
+	// Scheduled content stays unpublished until the publisher picks it up
+	db := config.GetDB()
+	var news []models.News
+	if err := db.Select("id, title, slug, publish_at").
+		Where("published = ? AND publish_at BETWEEN ? AND ?", false, from, to).
+		Find(&news).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	var events []models.Event
+	if err := db.Select("id, title, slug, publish_at").
+		Where("published = ? AND publish_at BETWEEN ? AND ?", false, from, to).
+		Find(&events).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	items := make([]ScheduledItem, 0, len(news)+len(events))
+	for _, n := range news {
+		items = append(items, ScheduledItem{ID: n.ID, Type: "NEWS", Title: n.Title, Slug: n.Slug, PublishAt: *n.PublishAt})
+	}
+	for _, e := range events {
+		items = append(items, ScheduledItem{ID: e.ID, Type: "EVENT", Title: e.Title, Slug: e.Slug, PublishAt: *e.PublishAt})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].PublishAt.Before(items[j].PublishAt)
+	})
+
+	utils.RespondSuccess(w, http.StatusOK, ScheduledResponse{
+		From:  from,
+		To:    to,
+		Items: items,
+	}, nil)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+)
+
+// Per-group result limits for the admin search
+const (
+	searchDefaultLimit = 5
+	searchMaxLimit     = 20
+)
+
+// SearchContentResult is a news or event search hit
+type SearchContentResult struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Published bool   `json:"published"`
+}
+
+// SearchHoleResult is a hole search hit
+type SearchHoleResult struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	HoleIndex int    `json:"hole_index"`
+}
+
+// SearchUserResult is a user search hit
+type SearchUserResult struct {
+	ID    string      `json:"id"`
+	Name  string      `json:"name"`
+	Email string      `json:"email"`
+	Role  models.Role `json:"role"`
+}
+
+// SearchResponse groups admin search hits by type
+type SearchResponse struct {
+	Query  string                `json:"query"`
+	News   []SearchContentResult `json:"news"`
+	Events []SearchContentResult `json:"events"`
+	Holes  []SearchHoleResult    `json:"holes"`
+	Users  []SearchUserResult    `json:"users"`
+}
+
+// AdminSearch searches news, events, holes and users case-insensitively
+// Query params: q (required), limit (results per group, default 5, max 20)
+func AdminSearch(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		utils.RespondValidationError(w, map[string]string{
+			"q": "Search query is required",
+		})
+		return
+	}
+
+	limit := searchDefaultLimit
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= searchMaxLimit {
+		limit = l
+	}
+
+	db := config.GetDB()
+	pattern := utils.ContainsPattern(q)
+	response := SearchResponse{
+		Query:  q,
+		News:   []SearchContentResult{},
+		Events: []SearchContentResult{},
+		Holes:  []SearchHoleResult{},
+		Users:  []SearchUserResult{},
+	}
+
+	// Search news titles and content
+	if err := db.Model(&models.News{}).
+		Where(`LOWER(title) LIKE ? ESCAPE '\' OR LOWER(content) LIKE ? ESCAPE '\'`, pattern, pattern).
+		Order("created_at DESC, id DESC").Limit(limit).
+		Find(&response.News).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Search event titles and content
+	if err := db.Model(&models.Event{}).
+		Where(`LOWER(title) LIKE ? ESCAPE '\' OR LOWER(content) LIKE ? ESCAPE '\'`, pattern, pattern).
+		Order("created_at DESC, id DESC").Limit(limit).
+		Find(&response.Events).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Search hole names and descriptions
+	if err := db.Model(&models.Hole{}).
+		Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\'`, pattern, pattern).
+		Order("hole_index ASC").Limit(limit).
+		Find(&response.Holes).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Search user names and emails
+	if err := db.Model(&models.User{}).
+		Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\'`, pattern, pattern).
+		Order("name ASC, id ASC").Limit(limit).
+		Find(&response.Users).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, response, nil)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// GetUserSessions lists the active sessions (issued, unrevoked tokens) of a user
+func GetUserSessions(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	// Make sure the user exists
+	db := config.GetDB()
+	var user models.User
+	if err := db.Where("id = ?", id).First(&user).Error; err != nil {
+		utils.RespondNotFound(w, "User")
+		return
+	}
+
+	// Sessions are tracked in Redis only
+	if !utils.IsRedisAvailable() {
+		respondSessionsUnavailable(w)
+		return
+	}
+
+	sessions, err := utils.ListSessions(r.Context(), user.ID)
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"sessions": sessions,
+	}, nil)
+}
+
+// RevokeUserSession revokes one session of a user so its token stops working
+func RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+	sessionID := params["sessionId"]
+
+	// Sessions are tracked in Redis only
+	if !utils.IsRedisAvailable() {
+		respondSessionsUnavailable(w)
+		return
+	}
+
+	err := utils.RevokeSession(r.Context(), id, sessionID)
+	if errors.Is(err, utils.ErrSessionNotFound) {
+		utils.RespondNotFound(w, "Session")
+		return
+	}
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	audit(r, "session.revoke", "user", id, "session="+sessionID)
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "Session revoked successfully",
+	}, nil)
+}
+
+// respondSessionsUnavailable reports that session management needs Redis
+func respondSessionsUnavailable(w http.ResponseWriter) {
+	utils.RespondError(w, http.StatusServiceUnavailable, "SESSIONS_UNAVAILABLE", "Session tracking is unavailable without Redis", nil)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// SimilarNews is an article whose content resembles the one being checked
+type SimilarNews struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	Slug      string  `json:"slug"`
+	Published bool    `json:"published"`
+	Score     float64 `json:"score"`
+}
+
+// similarityThreshold returns the minimum score for an article to count as
+// similar, from SIMILARITY_THRESHOLD (default 0.5)
+func similarityThreshold() float64 {
+	if v, err := strconv.ParseFloat(config.GetEnv("SIMILARITY_THRESHOLD", ""), 64); err == nil && v > 0 && v <= 1 {
+		return v
+	}
+	return 0.5
+}
+
+// similarityScanLimit returns how many of the most recently updated articles
+// are compared, from SIMILARITY_SCAN_LIMIT (default 500)
+func similarityScanLimit() int {
+	if v, err := strconv.Atoi(config.GetEnv("SIMILARITY_SCAN_LIMIT", "")); err == nil && v > 0 {
+		return v
+	}
+	return 500
+}
+
+// GetSimilarNews lists other articles whose content is similar to a news
+// article, most similar first, to catch accidental duplicates
+func GetSimilarNews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+
+	db := config.GetDB()
+	var news models.News
+	if err := db.Select("id, content, updated_at").Where("id = ?", id).First(&news).Error; err != nil {
+		utils.RespondNotFound(w, "News")
+		return
+	}
+
+	threshold := similarityThreshold()
+	scanLimit := similarityScanLimit()
+
+	// Try cache first. The key changes whenever the article is edited.
+	cacheKey := utils.BuildCacheKey("news", "similar", news.ID, news.UpdatedAt.UnixNano(), "threshold", threshold, "scan", scanLimit)
+	var cached []SimilarNews
+	if err := cacheGet(ctx, r, cacheKey, &cached); err == nil {
+		utils.RespondSuccess(w, http.StatusOK, cached, nil)
+		return
+	}
+
+	// Compare against the most recently updated other articles
+	var candidates []models.News
+	if err := db.Select("id, title, slug, published, content").
+		Where("id <> ?", news.ID).
+		Order("updated_at DESC").Limit(scanLimit).
+		Find(&candidates).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	shingles := utils.ContentShingles(news.Content)
+	similar := []SimilarNews{}
+	for _, candidate := range candidates {
+		score := utils.ShingleSimilarity(shingles, utils.ContentShingles(candidate.Content))
+		if score >= threshold {
+			similar = append(similar, SimilarNews{
+				ID:        candidate.ID,
+				Title:     candidate.Title,
+				Slug:      candidate.Slug,
+				Published: candidate.Published,
+				Score:     score,
+			})
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Score > similar[j].Score
+	})
+
+	// Cache the result
+	_ = utils.CacheSet(ctx, cacheKey, similar, utils.CacheTTLSimilarNews)
+
+	utils.RespondSuccess(w, http.StatusOK, similar, nil)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+)
+
+// siteURL returns the public website's base URL from SITE_URL. It is the
+// frontend host, unlike BASE_URL which is the API host.
+func siteURL() string {
+	return strings.TrimSuffix(config.GetEnv("SITE_URL", "http://localhost:3000"), "/")
+}
+
+// writeSitemapURL writes one <url> element
+func writeSitemapURL(w io.Writer, loc string, lastmod time.Time) {
+	io.WriteString(w, "  <url>\n    <loc>")
+	xml.EscapeText(w, []byte(loc))
+	io.WriteString(w, "</loc>\n")
+	if !lastmod.IsZero() {
+		fmt.Fprintf(w, "    <lastmod>%s</lastmod>\n", lastmod.UTC().Format(time.RFC3339))
+	}
+	io.WriteString(w, "  </url>\n")
+}
+
+// writeSitemapEntries streams a <url> for every published, not deleted row of
+// model, with pages at base + "/" + slug
+func writeSitemapEntries(w io.Writer, model interface{}, base string) error {
+	rows, err := config.GetDB().Model(model).
+		Select("slug, updated_at").
+		Where("published = ?", true).
+		Order("updated_at DESC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slug string
+		var updatedAt time.Time
+		if err := rows.Scan(&slug, &updatedAt); err != nil {
+			return err
+		}
+		writeSitemapURL(w, base+"/"+slug, updatedAt)
+	}
+	return rows.Err()
+}
+
+// GetSitemap streams sitemap.xml with the public pages of every published
+// news article and event plus the holes page, for search engines. URLs are
+// built from SITE_URL.
+func GetSitemap(w http.ResponseWriter, r *http.Request) {
+	base := siteURL()
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, xml.Header)
+	io.WriteString(w, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+"\n")
+
+	// The holes page changes whenever any hole does
+	var holesUpdated *time.Time
+	if err := config.GetDB().Model(&models.Hole{}).Select("MAX(updated_at)").Scan(&holesUpdated).Error; err != nil {
+		log.Printf("Warning: Failed to read holes for sitemap: %v", err)
+	}
+	var lastmod time.Time
+	if holesUpdated != nil {
+		lastmod = *holesUpdated
+	}
+	writeSitemapURL(w, base+"/holes", lastmod)
+
+	// The status line is already sent, so failures can only be logged
+	if err := writeSitemapEntries(w, &models.News{}, base+"/news"); err != nil {
+		log.Printf("Warning: Failed to write news to sitemap: %v", err)
+	}
+	if err := writeSitemapEntries(w, &models.Event{}, base+"/events"); err != nil {
+		log.Printf("Warning: Failed to write events to sitemap: %v", err)
+	}
+
+	io.WriteString(w, "</urlset>\n")
+}
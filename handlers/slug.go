@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"gorm.io/gorm"
+)
+
+// autoSlugOnTitle reports whether a draft's slug should follow its new title.
+// The auto_slug_on_title form field overrides the AUTO_SLUG_ON_TITLE default.
+func autoSlugOnTitle(r *http.Request) bool {
+	if value, ok := formValue(r, "auto_slug_on_title"); ok {
+		return value == "true"
+	}
+	return config.GetEnv("AUTO_SLUG_ON_TITLE", "false") == "true"
+}
+
+// recordSlugChange keeps the previous slug of a news article or event in the slug history
+func recordSlugChange(tx *gorm.DB, entityType, entityID, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+	return tx.Create(&models.SlugHistory{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Slug:       oldSlug,
+	}).Error
+}
+
+// maxSlugCheck bounds how many slugs one availability check may contain
+const maxSlugCheck = 500
+
+// SlugCheckRequest lists slugs to check for one content type
+type SlugCheckRequest struct {
+	Type  string   `json:"type"` // "news" or "event"
+	Slugs []string `json:"slugs"`
+}
+
+// SlugCheckResult reports whether a slug can be used for a new item
+type SlugCheckResult struct {
+	Slug      string `json:"slug"`
+	Available bool   `json:"available"`
+	// Why the slug can't be used: invalid, duplicate (earlier in the same
+	// request), taken, or deleted (held by a soft-deleted item)
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"` // Validation message when invalid
+	// The other content type already uses the slug. It lives under a
+	// different URL, so this doesn't make the slug unavailable.
+	UsedByOtherType bool `json:"used_by_other_type"`
+}
+
+// slugUsage is a row of the slug lookup across news and events
+type slugUsage struct {
+	Slug      string
+	Type      string
+	DeletedAt *time.Time
+}
+
+// CheckSlugs reports, for each slug, whether it is free for the given
+// content type, looking all of them up in a single query (admin only)
+func CheckSlugs(w http.ResponseWriter, r *http.Request) {
+	var req SlugCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondBadRequest(w, "Invalid request payload")
+		return
+	}
+
+	// Validate input
+	fields := make(map[string]string)
+	if req.Type != "news" && req.Type != "event" {
+		fields["type"] = "Type must be 'news' or 'event'"
+	}
+	if len(req.Slugs) == 0 {
+		fields["slugs"] = "At least one slug is required"
+	} else if len(req.Slugs) > maxSlugCheck {
+		fields["slugs"] = fmt.Sprintf("At most %d slugs can be checked at once", maxSlugCheck)
+	}
+	if len(fields) > 0 {
+		utils.RespondValidationError(w, fields)
+		return
+	}
+
+	// Look up every slug in both tables at once, soft-deleted rows included
+	// since they still hold the unique index
+	var usages []slugUsage
+	db := config.GetDB()
+	if err := db.Raw(`SELECT slug, 'news' AS type, deleted_at FROM news WHERE slug IN ?
+		UNION ALL
+		SELECT slug, 'event' AS type, deleted_at FROM events WHERE slug IN ?`, req.Slugs, req.Slugs).
+		Scan(&usages).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	own := make(map[string]slugUsage)
+	other := make(map[string]bool)
+	for _, usage := range usages {
+		if usage.Type == req.Type {
+			own[usage.Slug] = usage
+		} else {
+			other[usage.Slug] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(req.Slugs))
+	results := make([]SlugCheckResult, len(req.Slugs))
+	for i, slug := range req.Slugs {
+		result := SlugCheckResult{Slug: slug, UsedByOtherType: other[slug]}
+		usage, taken := own[slug]
+		switch {
+		case slug == "":
+			result.Reason, result.Error = "invalid", "Slug is required"
+		case utils.IsIDLike(slug):
+			result.Reason, result.Error = "invalid", "Slug must not look like an ID"
+		case fieldLengthError("slug", slug) != "":
+			result.Reason, result.Error = "invalid", fieldLengthError("slug", slug)
+		case seen[slug]:
+			result.Reason = "duplicate"
+		case taken && usage.DeletedAt != nil:
+			result.Reason = "deleted"
+		case taken:
+			result.Reason = "taken"
+		default:
+			result.Available = true
+		}
+		seen[slug] = true
+		results[i] = result
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"type":    req.Type,
+		"results": results,
+	}, nil)
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+)
+
+// statsEntityTables maps the allowed entity query values to their tables
+var statsEntityTables = map[string]string{
+	"news":   "news",
+	"events": "events",
+}
+
+// statsMetrics are the allowed metric query values
+var statsMetrics = map[string]bool{
+	"created":   true,
+	"published": true,
+}
+
+// statsDefaultRange is the lookback used when "from" is not provided
+const statsDefaultRange = 30 * 24 * time.Hour
+
+// statsMaxBuckets caps the number of buckets a single request may return
+const statsMaxBuckets = 1000
+
+// TimeseriesPoint is the count for a single time bucket
+type TimeseriesPoint struct {
+	Bucket string `json:"bucket"` // Bucket start date (YYYY-MM-DD)
+	Count  int64  `json:"count"`
+}
+
+// TimeseriesResponse represents the response for the timeseries stats endpoint
+type TimeseriesResponse struct {
+	Metric string            `json:"metric"`
+	Entity string            `json:"entity"`
+	Bucket string            `json:"bucket"`
+	From   time.Time         `json:"from"`
+	To     time.Time         `json:"to"`
+	Points []TimeseriesPoint `json:"points"`
+}
+
+// truncateToBucket returns the start of the bucket containing t (weeks start on Monday)
+func truncateToBucket(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch bucket {
+	case "week":
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// nextBucket returns the start of the bucket following start
+func nextBucket(start time.Time, bucket string) time.Time {
+	switch bucket {
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "month":
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// countBuckets returns the number of buckets between from and to, stopping past statsMaxBuckets
+func countBuckets(from, to time.Time, bucket string) int {
+	n := 0
+	for start := truncateToBucket(from, bucket); !start.After(to) && n <= statsMaxBuckets; start = nextBucket(start, bucket) {
+		n++
+	}
+	return n
+}
+
+// parseBucketValue normalizes a bucket value returned by the database to a date
+func parseBucketValue(value string) (time.Time, bool) {
+	layouts := []string{time.RFC3339Nano, "2006-01-02 15:04:05Z07:00", "2006-01-02 15:04:05", "2006-01-02"}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// GetTimeseriesStats returns content counts grouped into day, week or month buckets
+// Query params: metric (created|published), entity (news|events),
+// bucket (day|week|month), from and to (RFC3339 or YYYY-MM-DD)
+func GetTimeseriesStats(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	metric := query.Get("metric")
+	if metric == "" {
+		metric = "created"
+	}
+	entity := query.Get("entity")
+	if entity == "" {
+		entity = "news"
+	}
+	bucket := query.Get("bucket")
+	if bucket == "" {
+		bucket = "day"
+	}
+
+	// Validate input against allowlists
+	fields := make(map[string]string)
+	if !statsMetrics[metric] {
+		fields["metric"] = "Metric must be 'created' or 'published'"
+	}
+	table, ok := statsEntityTables[entity]
+	if !ok {
+		fields["entity"] = "Entity must be 'news' or 'events'"
+	}
+	if !utils.DateBuckets[bucket] {
+		fields["bucket"] = "Bucket must be 'day', 'week' or 'month'"
+	}
+
+	// Parse date range
+	to := time.Now().UTC()
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := utils.ParseDate(toStr)
+		if err != nil {
+			fields["to"] = "Invalid date format. Use RFC3339 or YYYY-MM-DD"
+		}
+		to = parsed.UTC()
+	}
+	from := to.Add(-statsDefaultRange)
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := utils.ParseDate(fromStr)
+		if err != nil {
+			fields["from"] = "Invalid date format. Use RFC3339 or YYYY-MM-DD"
+		}
+		from = parsed.UTC()
+	}
+	if len(fields) == 0 && from.After(to) {
+		fields["from"] = "From must be before to"
+	}
+	if len(fields) == 0 && countBuckets(from, to, bucket) > statsMaxBuckets {
+		fields["bucket"] = "Date range is too large for this bucket size"
+	}
+
+	if len(fields) > 0 {
+		utils.RespondValidationError(w, fields)
+		return
+	}
+
+	db := config.GetDB()
+
+	// Created counts group by the creation date, published counts by when the
+	// item went live: published_at, or created_at for rows published before it was recorded
+	column := "created_at"
+	if metric == "published" {
+		column = "COALESCE(published_at, created_at)"
+	}
+	bucketExpr := utils.DateTruncExpr(db, bucket, column)
+	dbQuery := db.Table(table).
+		Select(bucketExpr+" AS bucket, COUNT(*) AS count").
+		Where("deleted_at IS NULL").
+		Where(column+" BETWEEN ? AND ?", from, to)
+	if metric == "published" {
+		dbQuery = dbQuery.Where("published = ?", true)
+	}
+
+	var rows []struct {
+		Bucket string
+		Count  int64
+	}
+	if err := dbQuery.Group("bucket").Order("bucket").Scan(&rows).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		if t, ok := parseBucketValue(row.Bucket); ok {
+			counts[t.Format("2006-01-02")] += row.Count
+		}
+	}
+
+	// Fill every bucket in the range so gaps show up as zero
+	points := []TimeseriesPoint{}
+	for start := truncateToBucket(from, bucket); !start.After(to); start = nextBucket(start, bucket) {
+		key := start.Format("2006-01-02")
+		points = append(points, TimeseriesPoint{Bucket: key, Count: counts[key]})
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, TimeseriesResponse{
+		Metric: metric,
+		Entity: entity,
+		Bucket: bucket,
+		From:   from,
+		To:     to,
+		Points: points,
+	}, nil)
+}
+
+// dashboardRecentPosts is how many of the newest posts the dashboard lists
+const dashboardRecentPosts = 5
+
+// ContentCounts splits a content type's total into published and drafts
+type ContentCounts struct {
+	Total     int64 `json:"total"`
+	Published int64 `json:"published"`
+	Drafts    int64 `json:"drafts"`
+}
+
+// DashboardStats holds the counts shown on the admin home screen
+type DashboardStats struct {
+	News        ContentCounts  `json:"news"`
+	Events      ContentCounts  `json:"events"`
+	Holes       int64          `json:"holes"`
+	Users       int64          `json:"users"`
+	RecentPosts []PostResponse `json:"recent_posts"` // Newest news and events, drafts included
+}
+
+// invalidateDashboardStats clears the cached admin dashboard counts after a
+// hole or user change; content changes clear them through
+// utils.InvalidateContentCaches
+func invalidateDashboardStats(ctx context.Context) {
+	_ = utils.CacheDelete(ctx, utils.CacheKeyDashboardStats)
+}
+
+// countContent counts all and published rows of a news or event model
+func countContent(model interface{}) (ContentCounts, error) {
+	db := config.GetDB()
+	var counts ContentCounts
+	if err := db.Model(model).Count(&counts.Total).Error; err != nil {
+		return counts, err
+	}
+	if err := db.Model(model).Where("published = ?", true).Count(&counts.Published).Error; err != nil {
+		return counts, err
+	}
+	counts.Drafts = counts.Total - counts.Published
+	return counts, nil
+}
+
+// GetDashboardStats returns content, hole and user counts plus the newest
+// posts for the admin home screen in one response (admin only)
+func GetDashboardStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Try cache first
+	var stats DashboardStats
+	if err := cacheGet(ctx, r, utils.CacheKeyDashboardStats, &stats); err != nil {
+		// Cache miss - count each section
+		db := config.GetDB()
+		if stats.News, err = countContent(&models.News{}); err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+		if stats.Events, err = countContent(&models.Event{}); err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+		if err := db.Model(&models.Hole{}).Count(&stats.Holes).Error; err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+		if err := db.Model(&models.User{}).Count(&stats.Users).Error; err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+
+		// Newest of each type, merged newest first
+		var news []models.News
+		var events []models.Event
+		if err := db.Preload("Author").Order("created_at DESC, id DESC").Limit(dashboardRecentPosts).Find(&news).Error; err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+		if err := db.Preload("Author").Order("created_at DESC, id DESC").Limit(dashboardRecentPosts).Find(&events).Error; err != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+		recent := append(newsToPosts(news), eventsToPosts(events)...)
+		sort.SliceStable(recent, func(i, j int) bool {
+			return recent[i].CreatedAt.After(recent[j].CreatedAt)
+		})
+		if len(recent) > dashboardRecentPosts {
+			recent = recent[:dashboardRecentPosts]
+		}
+		stats.RecentPosts = recent
+
+		_ = utils.CacheSet(ctx, utils.CacheKeyDashboardStats, stats, utils.CacheTTLDashboardStats)
+	}
+
+	// Add BASE_URL to image URLs for response
+	baseURL := config.GetEnv("BASE_URL", "")
+	for i := range stats.RecentPosts {
+		stats.RecentPosts[i].ImageURL = utils.PrependBaseURL(stats.RecentPosts[i].ImageURL, baseURL)
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, stats, nil)
+}
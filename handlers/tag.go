@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"gorm.io/gorm"
+)
+
+// BulkTagRequest represents the request body for bulk tagging content
+type BulkTagRequest struct {
+	IDs    []string `json:"ids"`
+	Type   string   `json:"type"` // "news" or "event"
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+// errContentNotFound is returned when a bulk operation references unknown content
+var errContentNotFound = errors.New("content not found")
+
+// maxTagFilters caps how many tags a list can be filtered by at once
+const maxTagFilters = 10
+
+// tagJoin is the many2many table linking news or events to their tags
+type tagJoin struct {
+	table      string
+	foreignKey string
+}
+
+// Join tables of news and events
+var (
+	newsTags  = tagJoin{"news_tags", "news_id"}
+	eventTags = tagJoin{"event_tags", "event_id"}
+)
+
+// tagFilter selects content by tag slugs. With all set a row must carry
+// every tag; otherwise any one of them is enough.
+type tagFilter struct {
+	slugs []string
+	all   bool
+}
+
+// active reports whether the filter restricts anything
+func (f tagFilter) active() bool {
+	return len(f.slugs) > 0
+}
+
+// cacheKey folds the filter into a list cache key
+func (f tagFilter) cacheKey(key string) string {
+	if !f.active() {
+		return key
+	}
+	mode := "any"
+	if f.all {
+		mode = "all"
+	}
+	return utils.BuildCacheKey(key, "tag", mode, strings.Join(f.slugs, ","))
+}
+
+// condition builds the WHERE clause and its arguments selecting rows linked
+// through join to the filter's tags. Matching every tag groups the links per
+// row and keeps the rows linked to as many distinct tags as were asked for.
+func (f tagFilter) condition(join tagJoin) (string, []interface{}) {
+	subquery := "SELECT " + join.table + "." + join.foreignKey + " FROM " + join.table +
+		" JOIN tags ON tags.id = " + join.table + ".tag_id WHERE tags.slug IN ?"
+	if !f.all {
+		return "id IN (" + subquery + ")", []interface{}{f.slugs}
+	}
+	return "id IN (" + subquery + " GROUP BY " + join.table + "." + join.foreignKey +
+		" HAVING COUNT(DISTINCT tags.id) = ?)", []interface{}{f.slugs, len(f.slugs)}
+}
+
+// tagFilterParam reads the tag filter from one or more ?tag= slugs and
+// ?tag_mode=any (default) or all. Invalid input is answered with 400.
+func tagFilterParam(w http.ResponseWriter, r *http.Request) (tagFilter, bool) {
+	query := r.URL.Query()
+
+	var filter tagFilter
+	switch mode := query.Get("tag_mode"); mode {
+	case "", "any":
+	case "all":
+		filter.all = true
+	default:
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_TAG_MODE", "Tag mode must be 'any' or 'all'", nil)
+		return filter, false
+	}
+
+	// Normalize, skip blanks and drop duplicates
+	seen := make(map[string]bool)
+	for _, raw := range query["tag"] {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		slug := utils.GenerateSlug(raw)
+		if slug == "" {
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_TAG", "Invalid tag '"+raw+"'", nil)
+			return filter, false
+		}
+		if !seen[slug] {
+			seen[slug] = true
+			filter.slugs = append(filter.slugs, slug)
+		}
+	}
+	if len(filter.slugs) > maxTagFilters {
+		utils.RespondError(w, http.StatusBadRequest, "TOO_MANY_TAGS", fmt.Sprintf("Filter by at most %d tags", maxTagFilters), nil)
+		return filter, false
+	}
+
+	// Sorted so the same tags in any order share a cache key; with one tag
+	// both modes match the same rows
+	sort.Strings(filter.slugs)
+	if len(filter.slugs) < 2 {
+		filter.all = false
+	}
+	return filter, true
+}
+
+// applyTagFilter restricts query to rows of join matching the tag filter
+func applyTagFilter(query *gorm.DB, join tagJoin, filter tagFilter) *gorm.DB {
+	if !filter.active() {
+		return query
+	}
+	condition, args := filter.condition(join)
+	return query.Where(condition, args...)
+}
+
+// parseTagNames splits a comma-separated tags form field into tag names
+func parseTagNames(value string) []string {
+	names := []string{}
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseTags parses a comma-separated tags form field, rejecting it with 400
+// when a tag gives no slug
+func parseTags(w http.ResponseWriter, value string) ([]string, bool) {
+	names := parseTagNames(value)
+	return names, validTagNames(w, names)
+}
+
+// validTagNames responds 400 naming the tags that give no slug, e.g. ones
+// written only in CJK characters or emoji, instead of dropping them silently
+func validTagNames(w http.ResponseWriter, names []string) bool {
+	rejected := []string{}
+	for _, name := range names {
+		if utils.GenerateSlug(strings.TrimSpace(name)) == "" {
+			rejected = append(rejected, name)
+		}
+	}
+	if len(rejected) == 0 {
+		return true
+	}
+
+	utils.RespondError(w, http.StatusBadRequest, "INVALID_TAG",
+		fmt.Sprintf("Tags need at least one Latin letter or digit: %s", strings.Join(rejected, ", ")),
+		map[string][]string{"tags": rejected})
+	return false
+}
+
+// upsertTags finds or creates tags by name, keyed on the slug generated from
+// the name. Names must have passed validTagNames.
+func upsertTags(tx *gorm.DB, names []string) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := utils.GenerateSlug(name)
+		if slug == "" {
+			return nil, fmt.Errorf("tag %q has no slug", name)
+		}
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		tag := models.Tag{Name: name, Slug: slug}
+		if err := tx.Where("slug = ?", slug).FirstOrCreate(&tag).Error; err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// findTagsByName looks up existing tags by the slugs generated from the given names
+func findTagsByName(tx *gorm.DB, names []string) ([]models.Tag, error) {
+	slugs := []string{}
+	for _, name := range names {
+		if slug := utils.GenerateSlug(strings.TrimSpace(name)); slug != "" {
+			slugs = append(slugs, slug)
+		}
+	}
+
+	tags := []models.Tag{}
+	if len(slugs) == 0 {
+		return tags, nil
+	}
+	err := tx.Where("slug IN ?", slugs).Find(&tags).Error
+	return tags, err
+}
+
+// BulkTagContent adds and/or removes tags across many news articles or events
+// in a single transaction and returns the resulting tag set per item
+func BulkTagContent(w http.ResponseWriter, r *http.Request) {
+	var req BulkTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondBadRequest(w, "Invalid request payload")
+		return
+	}
+
+	// Validate input
+	fields := make(map[string]string)
+	if len(req.IDs) == 0 {
+		fields["ids"] = "At least one id is required"
+	}
+	if req.Type != "news" && req.Type != "event" {
+		fields["type"] = "Type must be 'news' or 'event'"
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		fields["add"] = "Provide tags to add and/or remove"
+	}
+	if len(fields) > 0 {
+		utils.RespondValidationError(w, fields)
+		return
+	}
+	if !validTagNames(w, req.Add) {
+		return
+	}
+
+	db := config.GetDB()
+	result := make(map[string][]models.Tag)
+	var slugs []string
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		addTags, err := upsertTags(tx, req.Add)
+		if err != nil {
+			return err
+		}
+		removeTags, err := findTagsByName(tx, req.Remove)
+		if err != nil {
+			return err
+		}
+
+		if req.Type == "news" {
+			var items []models.News
+			if err := tx.Where("id IN ?", req.IDs).Find(&items).Error; err != nil {
+				return err
+			}
+			if len(items) != len(uniqueStrings(req.IDs)) {
+				return errContentNotFound
+			}
+
+			for i := range items {
+				association := tx.Model(&items[i]).Association("Tags")
+				if len(addTags) > 0 {
+					if err := association.Append(addTags); err != nil {
+						return err
+					}
+				}
+				if len(removeTags) > 0 {
+					if err := association.Delete(removeTags); err != nil {
+						return err
+					}
+				}
+
+				var tags []models.Tag
+				if err := tx.Model(&items[i]).Association("Tags").Find(&tags); err != nil {
+					return err
+				}
+				result[items[i].ID] = tags
+				slugs = append(slugs, items[i].Slug)
+			}
+			return nil
+		}
+
+		var items []models.Event
+		if err := tx.Where("id IN ?", req.IDs).Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) != len(uniqueStrings(req.IDs)) {
+			return errContentNotFound
+		}
+
+		for i := range items {
+			association := tx.Model(&items[i]).Association("Tags")
+			if len(addTags) > 0 {
+				if err := association.Append(addTags); err != nil {
+					return err
+				}
+			}
+			if len(removeTags) > 0 {
+				if err := association.Delete(removeTags); err != nil {
+					return err
+				}
+			}
+
+			var tags []models.Tag
+			if err := tx.Model(&items[i]).Association("Tags").Find(&tags); err != nil {
+				return err
+			}
+			result[items[i].ID] = tags
+			slugs = append(slugs, items[i].Slug)
+		}
+		return nil
+	})
+
+	if errors.Is(err, errContentNotFound) {
+		utils.RespondValidationError(w, map[string]string{
+			"ids": "One or more ids do not exist",
+		})
+		return
+	}
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	audit(r, "content.tag", req.Type, "", "ids="+strings.Join(req.IDs, ",")+" add="+strings.Join(req.Add, ",")+" remove="+strings.Join(req.Remove, ","))
+
+	// Invalidate caches of the affected items
+	utils.InvalidateContentCaches(r.Context(), req.Type, req.IDs, slugs)
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"items": result,
+	}, nil)
+}
+
+// uniqueStrings returns the input without duplicates, preserving order
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"sentul-golf-be/models"
+)
+
+func TestValidTagNames(t *testing.T) {
+	tests := []struct {
+		name         string
+		names        []string
+		wantOK       bool
+		wantRejected []string
+	}{
+		{"no tags", []string{}, true, nil},
+		{"latin tags", []string{"Golf", "Tournament 2024"}, true, nil},
+		{"accented tag transliterates", []string{"Café"}, true, nil},
+		{"CJK tag", []string{"golf", "高尔夫"}, false, []string{"高尔夫"}},
+		{"every bad tag is named", []string{"🏌️", "golf", "ゴルフ"}, false, []string{"🏌️", "ゴルフ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if ok := validTagNames(w, tt.names); ok != tt.wantOK {
+				t.Fatalf("validTagNames() = %v, want %v", ok, tt.wantOK)
+			}
+			if tt.wantOK {
+				if w.Body.Len() != 0 {
+					t.Errorf("wrote a response for valid tags: %s", w.Body.String())
+				}
+				return
+			}
+
+			if w.Code != http.StatusBadRequest || errorCode(t, w) != "INVALID_TAG" {
+				t.Fatalf("response = %d %s, want 400 INVALID_TAG", w.Code, w.Body.String())
+			}
+			var body struct {
+				Error struct {
+					Details struct {
+						Tags []string `json:"tags"`
+					} `json:"details"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(body.Error.Details.Tags, tt.wantRejected) {
+				t.Errorf("rejected tags = %v, want %v", body.Error.Details.Tags, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestBulkTagContentRejectsTagsWithoutSlug(t *testing.T) {
+	tests := []struct {
+		name       string
+		add        []string
+		remove     []string
+		wantStatus int
+	}{
+		{"latin tag added", []string{"golf"}, nil, http.StatusOK},
+		{"CJK tag added", []string{"golf", "高尔夫"}, nil, http.StatusBadRequest},
+		{"CJK tag removed matches nothing", nil, []string{"高尔夫"}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+			news := models.News{Title: "Opening Day", Content: "x", Slug: "opening-day", AuthorID: admin.ID}
+			if err := db.Create(&news).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			r := newJSONRequest(t, http.MethodPost, "/api/tags/bulk", BulkTagRequest{
+				IDs:    []string{news.ID},
+				Type:   "news",
+				Add:    tt.add,
+				Remove: tt.remove,
+			})
+			w := httptest.NewRecorder()
+			BulkTagContent(w, withClaims(r, admin.ID, models.RoleAdmin))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				var count int64
+				db.Model(&models.Tag{}).Count(&count)
+				if count != 0 {
+					t.Errorf("created %d tags on a rejected request", count)
+				}
+			}
+		})
+	}
+}
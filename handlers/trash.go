@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// TrashedItem is a soft-deleted news article, event or hole
+type TrashedItem struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"` // The name, for holes
+	Slug      string    `json:"slug,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// wantsTrash reports whether a list request asked for deleted items (?deleted=true)
+func wantsTrash(r *http.Request) bool {
+	return r.URL.Query().Get("deleted") == "true"
+}
+
+// respondTrash lists the soft-deleted rows matched by query, most recently
+// deleted first. columns selects id, title, slug and deleted_at in that shape.
+func respondTrash(w http.ResponseWriter, r *http.Request, key string, query *gorm.DB, columns string) {
+	page, limit, offset := utils.ParsePagination(r, utils.DefaultPageLimit)
+
+	query = query.Unscoped().Where("deleted_at IS NOT NULL").Session(&gorm.Session{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	items := []TrashedItem{}
+	if err := query.Select(columns).Order("deleted_at DESC, id DESC").Limit(limit).Offset(offset).Scan(&items).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	utils.RespondList(w, r, key, items, utils.NewMeta(page, limit, total))
+}
+
+// restoreContent brings a soft-deleted news article or event back as an
+// unpublished draft for an editor to review. The slug needs no check: the
+// unique index covers soft-deleted rows, so no newer item can have taken it.
+// A non-empty authorID limits the restore to that author's items.
+func restoreContent(w http.ResponseWriter, r *http.Request, item interface{}, resource, authorID string) (bool, string) {
+	id := mux.Vars(r)["id"]
+
+	db := config.GetDB()
+	query := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id)
+	if authorID != "" {
+		query = query.Where("author_id = ?", authorID)
+	}
+	if err := query.First(item).Error; err != nil {
+		utils.RespondNotFound(w, "Deleted "+resource)
+		return false, ""
+	}
+
+	if err := db.Unscoped().Model(item).Updates(map[string]interface{}{
+		"deleted_at": nil,
+		"published":  false,
+		"publish_at": nil,
+	}).Error; err != nil {
+		utils.RespondInternalError(w)
+		return false, ""
+	}
+
+	return true, id
+}
+
+// RestoreNews restores a soft-deleted news article as a draft (admin only)
+func RestoreNews(w http.ResponseWriter, r *http.Request) {
+	var news models.News
+	ok, id := restoreContent(w, r, &news, "News", "")
+	if !ok {
+		return
+	}
+
+	audit(r, "news.restore", "news", id, "slug="+news.Slug)
+	utils.InvalidateContentCaches(r.Context(), "news", []string{id}, []string{news.Slug})
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"message": "News restored as a draft",
+		"id":      id,
+		"slug":    news.Slug,
+	}, nil)
+}
+
+// GetMyNewsTrash lists the soft-deleted news authored by the calling user
+func GetMyNewsTrash(w http.ResponseWriter, r *http.Request) {
+	claims, ok := requireClaims(w, r)
+	if !ok {
+		return
+	}
+
+	query := config.GetDB().Model(&models.News{}).Where("author_id = ?", claims.UserID)
+	respondTrash(w, r, "news", query, "id, title, slug, deleted_at")
+}
+
+// RestoreMyNews restores a soft-deleted news article authored by the calling
+// user as a draft. Other users' items are reported as not found.
+func RestoreMyNews(w http.ResponseWriter, r *http.Request) {
+	claims, ok := requireClaims(w, r)
+	if !ok {
+		return
+	}
+
+	var news models.News
+	restored, id := restoreContent(w, r, &news, "News", claims.UserID)
+	if !restored {
+		return
+	}
+
+	audit(r, "news.restore", "news", id, "slug="+news.Slug)
+	utils.InvalidateContentCaches(r.Context(), "news", []string{id}, []string{news.Slug})
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"message": "News restored as a draft",
+		"id":      id,
+		"slug":    news.Slug,
+	}, nil)
+}
+
+// RestoreEvent restores a soft-deleted event as a draft (admin only)
+func RestoreEvent(w http.ResponseWriter, r *http.Request) {
+	var event models.Event
+	ok, id := restoreContent(w, r, &event, "Event", "")
+	if !ok {
+		return
+	}
+
+	audit(r, "event.restore", "event", id, "slug="+event.Slug)
+	utils.InvalidateContentCaches(r.Context(), "event", []string{id}, []string{event.Slug})
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"message": "Event restored as a draft",
+		"id":      id,
+		"slug":    event.Slug,
+	}, nil)
+}
+
+// RestoreHole restores a soft-deleted hole at the end of the course order
+func RestoreHole(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	db := config.GetDB()
+	var hole models.Hole
+	if err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&hole).Error; err != nil {
+		utils.RespondNotFound(w, "Deleted hole")
+		return
+	}
+
+	// Append after the last hole so indices don't clash
+	var maxIndex int
+	var lastHole models.Hole
+	if err := db.Order("hole_index DESC").First(&lastHole).Error; err == nil {
+		maxIndex = lastHole.HoleIndex
+	}
+
+	if err := db.Unscoped().Model(&hole).Updates(map[string]interface{}{
+		"deleted_at": nil,
+		"hole_index": maxIndex + 1,
+	}).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	hole.HoleIndex = maxIndex + 1
+	audit(r, "hole.restore", "hole", id, "name="+hole.Name)
+	hole.ImageURL = utils.PrependBaseURL(hole.ImageURL, config.GetEnv("BASE_URL", ""))
+	invalidateHoleCaches(r.Context(), id)
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"message": "Hole restored",
+		"data":    hole,
+	}, nil)
+}
+
+// findPurgeable loads the item with the given id, deleted or not. Items that
+// aren't in the trash can only be purged with ?force=true.
+func findPurgeable(w http.ResponseWriter, r *http.Request, item interface{}, resource string) (string, bool) {
+	id := mux.Vars(r)["id"]
+
+	db := config.GetDB()
+	if err := db.Unscoped().Where("id = ?", id).First(item).Error; err != nil {
+		utils.RespondNotFound(w, resource)
+		return "", false
+	}
+
+	// The default scope only counts the item while it isn't deleted
+	var live int64
+	if err := db.Model(item).Where("id = ?", id).Count(&live).Error; err != nil {
+		utils.RespondInternalError(w)
+		return "", false
+	}
+	if live > 0 && r.URL.Query().Get("force") != "true" {
+		utils.RespondError(w, http.StatusConflict, "NOT_IN_TRASH", resource+" is not deleted. Delete it first or pass force=true", nil)
+		return "", false
+	}
+	return id, true
+}
+
+// purgeContent permanently removes a news article or event with its tag
+// links and slug history, then deletes its thumbnail, inline and gallery images
+func purgeContent(item interface{}, entity, id, imageURL, content string) error {
+	db := config.GetDB()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(item).Association("Tags").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Where("entity_type = ? AND entity_id = ?", entity, id).Delete(&models.SlugHistory{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(item).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	utils.DeleteImage(imageURL)
+	utils.DeleteContentImages(content)
+	deleteGallery(db, entity, id)
+	return nil
+}
+
+// PurgeNews permanently deletes a news article and its image files (admin only)
+func PurgeNews(w http.ResponseWriter, r *http.Request) {
+	var news models.News
+	id, ok := findPurgeable(w, r, &news, "News")
+	if !ok {
+		return
+	}
+
+	if err := purgeContent(&news, "news", id, news.ImageURL, news.Content); err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	audit(r, "news.purge", "news", id, "slug="+news.Slug)
+	utils.InvalidateContentCaches(r.Context(), "news", []string{id}, []string{news.Slug})
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "News permanently deleted",
+	}, nil)
+}
+
+// PurgeEvent permanently deletes an event and its image files (admin only)
+func PurgeEvent(w http.ResponseWriter, r *http.Request) {
+	var event models.Event
+	id, ok := findPurgeable(w, r, &event, "Event")
+	if !ok {
+		return
+	}
+
+	if err := purgeContent(&event, "event", id, event.ImageURL, event.Content); err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	audit(r, "event.purge", "event", id, "slug="+event.Slug)
+	utils.InvalidateContentCaches(r.Context(), "event", []string{id}, []string{event.Slug})
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "Event permanently deleted",
+	}, nil)
+}
+
+// PurgeHole permanently deletes a hole and its image file (admin only)
+func PurgeHole(w http.ResponseWriter, r *http.Request) {
+	var hole models.Hole
+	id, ok := findPurgeable(w, r, &hole, "Hole")
+	if !ok {
+		return
+	}
+
+	if err := config.GetDB().Unscoped().Delete(&hole).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+	utils.DeleteImage(hole.ImageURL)
+
+	audit(r, "hole.purge", "hole", id, "name="+hole.Name)
+	invalidateHoleCaches(r.Context(), id)
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "Hole permanently deleted",
+	}, nil)
+}
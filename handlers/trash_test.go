@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+func TestTrashActionsAreAudited(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		method     string
+		create     func(t *testing.T, db *gorm.DB, authorID string) string
+		wantAction string
+		wantTarget string
+	}{
+		{"restore hole", RestoreHole, http.MethodPost, trashedHole, "hole.restore", "hole"},
+		{"purge hole", PurgeHole, http.MethodDelete, trashedHole, "hole.purge", "hole"},
+		{"restore news", RestoreNews, http.MethodPost, trashedNews, "news.restore", "news"},
+		{"purge news", PurgeNews, http.MethodDelete, trashedNews, "news.purge", "news"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			setupTestUploads(t)
+			admin := createTestUser(t, db, "admin@example.com", models.RoleAdmin)
+			id := tt.create(t, db, admin.ID)
+
+			// Drop entries queued by earlier tests
+			utils.FlushAuditLogs()
+			db.Where("1 = 1").Delete(&models.AuditLog{})
+
+			r := httptest.NewRequest(tt.method, "/api/trash/"+id, nil)
+			r = mux.SetURLVars(withClaims(r, admin.ID, models.RoleAdmin), map[string]string{"id": id})
+			w := httptest.NewRecorder()
+			tt.handler(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+			if err := utils.FlushAuditLogs(); err != nil {
+				t.Fatalf("FlushAuditLogs() error = %v", err)
+			}
+
+			var entries []models.AuditLog
+			db.Find(&entries)
+			if len(entries) != 1 {
+				t.Fatalf("got %d audit entries, want 1", len(entries))
+			}
+			entry := entries[0]
+			if entry.Action != tt.wantAction || entry.TargetType != tt.wantTarget || entry.TargetID != id || entry.ActorID != admin.ID {
+				t.Errorf("audit entry = %s on %s %s by %s, want %s on %s %s by %s",
+					entry.Action, entry.TargetType, entry.TargetID, entry.ActorID, tt.wantAction, tt.wantTarget, id, admin.ID)
+			}
+		})
+	}
+}
+
+// trashedHole inserts a soft-deleted hole and returns its ID
+func trashedHole(t *testing.T, db *gorm.DB, _ string) string {
+	t.Helper()
+
+	hole := models.Hole{Name: "Hole 1", HoleIndex: 1}
+	if err := db.Create(&hole).Error; err != nil {
+		t.Fatalf("create hole: %v", err)
+	}
+	db.Delete(&hole)
+	return hole.ID
+}
+
+// trashedNews inserts a soft-deleted news article and returns its ID
+func trashedNews(t *testing.T, db *gorm.DB, authorID string) string {
+	t.Helper()
+
+	news := models.News{Title: "Old news", Content: "<p>Old</p>", Slug: "old-news", AuthorID: authorID}
+	if err := db.Create(&news).Error; err != nil {
+		t.Fatalf("create news: %v", err)
+	}
+	db.Delete(&news)
+	return news.ID
+}
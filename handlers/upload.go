@@ -1,7 +1,13 @@
 package handlers
 
 import (
+	"fmt"
+	"log"
+	"mime"
 	"net/http"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"sentul-golf-be/config"
@@ -12,8 +18,8 @@ import (
 // Accepts: multipart/form-data with field "image"
 // Returns: { "url": "http://..." } — URL siap dipakai di <img src="...">
 func UploadContentImage(w http.ResponseWriter, r *http.Request) {
-	// Parse multipart form (max 5MB)
-	if err := r.ParseMultipartForm(5 << 20); err != nil {
+	// Parse multipart form, keeping up to the image size limit in memory
+	if err := r.ParseMultipartForm(utils.MaxImageSize); err != nil {
 		utils.RespondBadRequest(w, "Failed to parse form data")
 		return
 	}
@@ -28,7 +34,7 @@ func UploadContentImage(w http.ResponseWriter, r *http.Request) {
 	// Validate & save to ./uploads/content/
 	imageResult, err := utils.SaveImage(file, header, "content")
 	if err != nil {
-		utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+		respondImageSaveError(w, r, err)
 		return
 	}
 
@@ -41,6 +47,33 @@ func UploadContentImage(w http.ResponseWriter, r *http.Request) {
 	}, nil)
 }
 
+// ValidateImage checks an uploaded image without saving it, so the editor can
+// reject bad files before the rest of the form is filled in.
+// Accepts: multipart/form-data with field "image"
+// Returns: { "content_type", "width", "height", "size" } or the validation error
+func ValidateImage(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form, keeping up to the image size limit in memory
+	if err := r.ParseMultipartForm(utils.MaxImageSize); err != nil {
+		utils.RespondBadRequest(w, "Failed to parse form data")
+		return
+	}
+
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		utils.RespondBadRequest(w, "Image file is required")
+		return
+	}
+	defer file.Close()
+
+	info, err := utils.InspectImage(file, header)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_IMAGE", err.Error(), nil)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, info, nil)
+}
+
 // DeleteSingleContentImage deletes one specific content image by its URL.
 // Called by the frontend in real-time when a user removes an image from the rich text editor.
 // Security: only /uploads/content/ files can be deleted via this endpoint.
@@ -70,3 +103,95 @@ func DeleteSingleContentImage(w http.ResponseWriter, r *http.Request) {
 		"message": "Image deleted",
 	}, nil)
 }
+
+// uploadsOffloadHeader returns the header that hands upload files to the
+// reverse proxy, from UPLOADS_SENDFILE: "x-accel-redirect" (nginx) or
+// "x-sendfile" (apache). Empty, the default, serves files from Go.
+func uploadsOffloadHeader() string {
+	switch mode := strings.ToLower(config.GetEnv("UPLOADS_SENDFILE", "")); mode {
+	case "x-accel-redirect":
+		return "X-Accel-Redirect"
+	case "x-sendfile":
+		return "X-Sendfile"
+	case "":
+		return ""
+	default:
+		log.Printf("Warning: Unknown UPLOADS_SENDFILE %q, serving uploads directly", mode)
+		return ""
+	}
+}
+
+// offloadUpload lets the reverse proxy send the file instead of Go. nginx
+// gets the URI of its internal location (UPLOADS_ACCEL_PREFIX), apache the
+// absolute file path.
+func offloadUpload(w http.ResponseWriter, header, relPath, fullPath string) {
+	target := fullPath
+	if header == "X-Accel-Redirect" {
+		target = strings.TrimSuffix(config.GetEnv("UPLOADS_ACCEL_PREFIX", "/protected-uploads"), "/") + "/" + relPath
+	} else if abs, err := filepath.Abs(fullPath); err == nil {
+		target = abs
+	}
+
+	// The proxy keeps the Content-Type set here
+	if contentType := mime.TypeByExtension(filepath.Ext(fullPath)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set(header, target)
+	w.WriteHeader(http.StatusOK)
+}
+
+// ServeUploads serves stored upload files from ./uploads.
+// It uses http.ServeContent so Range / If-Range requests are honored
+// (206 Partial Content with Accept-Ranges: bytes), which lets large hole
+// panoramas load progressively. Directory listings are never exposed.
+// With UPLOADS_SENDFILE set, the reverse proxy sends the file instead.
+func ServeUploads(w http.ResponseWriter, r *http.Request) {
+	// Normalize the requested path and keep it inside the upload directory
+	relPath := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if relPath == "" {
+		http.NotFound(w, r)
+		return
+	}
+	fullPath := filepath.Join(utils.UploadDir, filepath.FromSlash(relPath))
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Let nginx or apache stream the bytes
+	if header := uploadsOffloadHeader(); header != "" {
+		offloadUpload(w, header, relPath, fullPath)
+		return
+	}
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// SweepOrphanUploads reports uploaded files that nothing in the database
+// references any more, and deletes them with ?dry_run=false (admin only).
+// Files younger than UPLOAD_ORPHAN_MIN_AGE are never touched.
+func SweepOrphanUploads(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") != "false"
+
+	report, err := utils.SweepOrphanUploads(r.Context(), utils.OrphanUploadMinAge(), dryRun)
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	if !dryRun {
+		audit(r, "uploads.sweep", "upload", "", fmt.Sprintf("deleted=%d", report.Deleted))
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, report, nil)
+}
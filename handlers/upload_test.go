@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// serveThroughProxy sends r to ServeUploads mounted like the router does. When
+// the response hands the file to the reverse proxy, it plays nginx or apache
+// and serves the named file itself, Range requests included.
+func serveThroughProxy(t *testing.T, r *http.Request, uploads string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	upstream := httptest.NewRecorder()
+	http.StripPrefix("/uploads/", http.HandlerFunc(ServeUploads)).ServeHTTP(upstream, r)
+
+	var file string
+	if target := upstream.Header().Get("X-Accel-Redirect"); target != "" {
+		prefix := "/protected-uploads/"
+		if !strings.HasPrefix(target, prefix) {
+			t.Fatalf("X-Accel-Redirect = %q, want it under %s", target, prefix)
+		}
+		file = filepath.Join(uploads, filepath.FromSlash(strings.TrimPrefix(target, prefix)))
+	} else if target := upstream.Header().Get("X-Sendfile"); target != "" {
+		if !filepath.IsAbs(target) {
+			t.Fatalf("X-Sendfile = %q, want an absolute path", target)
+		}
+		file = target
+	} else {
+		return upstream
+	}
+
+	if upstream.Body.Len() != 0 {
+		t.Errorf("offloaded response has a body of %d bytes", upstream.Body.Len())
+	}
+	w := httptest.NewRecorder()
+	http.ServeFile(w, r, file)
+	return w
+}
+
+func TestServeUploadsRange(t *testing.T) {
+	content := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+
+	tests := []struct {
+		name        string
+		rangeHeader string
+		wantStatus  int
+		wantBody    string
+		wantRange   string // Content-Range
+	}{
+		{"first ten bytes", "bytes=0-9", http.StatusPartialContent, "0123456789", "bytes 0-9/36"},
+		{"suffix", "bytes=-6", http.StatusPartialContent, "uvwxyz", "bytes 30-35/36"},
+		{"whole file", "", http.StatusOK, string(content), ""},
+		{"unsatisfiable", "bytes=100-", http.StatusRequestedRangeNotSatisfiable, "", "bytes */36"},
+	}
+
+	for _, mode := range []string{"", "x-accel-redirect", "x-sendfile"} {
+		for _, tt := range tests {
+			name := mode
+			if name == "" {
+				name = "direct"
+			}
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				t.Setenv("UPLOADS_SENDFILE", mode)
+				uploads := setupTestUploads(t)
+				dir := filepath.Join(uploads, "holes")
+				if err := os.MkdirAll(dir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "panorama.jpg"), content, 0o644); err != nil {
+					t.Fatal(err)
+				}
+
+				r := httptest.NewRequest(http.MethodGet, "/uploads/holes/panorama.jpg", nil)
+				if tt.rangeHeader != "" {
+					r.Header.Set("Range", tt.rangeHeader)
+				}
+				w := serveThroughProxy(t, r, uploads)
+
+				if w.Code != tt.wantStatus {
+					t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+				}
+				if got := w.Header().Get("Content-Range"); got != tt.wantRange {
+					t.Errorf("Content-Range = %q, want %q", got, tt.wantRange)
+				}
+				if tt.wantStatus == http.StatusRequestedRangeNotSatisfiable {
+					return
+				}
+				if got := w.Header().Get("Accept-Ranges"); got != "bytes" {
+					t.Errorf("Accept-Ranges = %q, want bytes", got)
+				}
+				if !bytes.Equal(w.Body.Bytes(), []byte(tt.wantBody)) {
+					t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+				}
+			})
+		}
+	}
+}
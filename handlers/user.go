@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"sentul-golf-be/config"
 	"sentul-golf-be/middleware"
@@ -10,15 +13,14 @@ import (
 	"sentul-golf-be/utils"
 
 	"github.com/gorilla/mux"
-	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 // GetCurrentUser retrieves current authenticated user info
 func GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	// Get user info from context (set by AuthMiddleware)
-	claims, ok := r.Context().Value(middleware.UserContextKey).(*utils.Claims)
+	claims, ok := requireClaims(w, r)
 	if !ok {
-		utils.RespondUnauthorized(w, "Unauthorized")
 		return
 	}
 
@@ -36,16 +38,117 @@ func GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		"email": user.Email,
 		"role":  user.Role,
 	}
+	if claims.ImpersonatedBy != "" {
+		userInfo["impersonated_by"] = claims.ImpersonatedBy
+	}
 
 	utils.RespondSuccess(w, http.StatusOK, userInfo, nil)
 }
 
-// GetUsers retrieves all users (admin only)
+// ChangePasswordRequest represents the request body for changing one's own password
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+// UpdateUserRequest holds the fields UpdateUser may change. Omitted fields
+// are left as they are; role is only applied for admins. Users changing their
+// own email or password must confirm it with their current password.
+type UpdateUserRequest struct {
+	Name            *string      `json:"name"`
+	Email           *string      `json:"email"`
+	Password        *string      `json:"password"`
+	Role            *models.Role `json:"role"`
+	CurrentPassword string       `json:"current_password"`
+}
+
+// ChangePassword lets the authenticated user change their own password
+func ChangePassword(w http.ResponseWriter, r *http.Request) {
+	claims, ok := requireClaims(w, r)
+	if !ok {
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondBadRequest(w, "Invalid request payload")
+		return
+	}
+
+	db := config.GetDB()
+	var user models.User
+	if err := db.Where("id = ?", claims.UserID).First(&user).Error; err != nil {
+		utils.RespondNotFound(w, "User")
+		return
+	}
+
+	// Validate input
+	fields := make(map[string]string)
+	if req.OldPassword == "" {
+		fields["old_password"] = "Old password is required"
+	} else if !utils.CheckPassword(req.OldPassword, user.Password) {
+		fields["old_password"] = "Old password is incorrect"
+	}
+	if err := utils.ValidatePassword(req.NewPassword); err != nil {
+		fields["new_password"] = "New password " + err.Error()
+	}
+	if len(fields) > 0 {
+		utils.RespondValidationError(w, fields)
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	if err := db.Model(&user).Update("password", hashedPassword).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{
+		"message": "Password changed successfully",
+	}, nil)
+}
+
+// GetUsers retrieves users with pagination (admin only)
+// Query params: page, limit, search (name or email), role (admin or user)
 func GetUsers(w http.ResponseWriter, r *http.Request) {
+	page, limit, offset := utils.ParsePagination(r, utils.DefaultPageLimit)
+	search := searchParam(r)
+
+	// Optional role filter
+	role := models.Role(r.URL.Query().Get("role"))
+	if role != "" && role != models.RoleAdmin && role != models.RoleUser {
+		utils.RespondValidationError(w, map[string]string{
+			"role": "Role must be admin or user",
+		})
+		return
+	}
+
 	db := config.GetDB()
-	var users []models.User
+	query := db.Model(&models.User{})
+	if search != "" {
+		pattern := utils.ContainsPattern(search)
+		query = query.Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\'`, pattern, pattern)
+	}
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+	query = query.Session(&gorm.Session{})
 
-	if err := db.Find(&users).Error; err != nil {
+	// Count total items
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		utils.RespondInternalError(w)
+		return
+	}
+
+	// Get paginated results
+	var users []models.User
+	if err := query.Order("name ASC, id ASC").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
 		utils.RespondInternalError(w)
 		return
 	}
@@ -55,9 +158,7 @@ func GetUsers(w http.ResponseWriter, r *http.Request) {
 		users[i].Password = ""
 	}
 
-	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
-"users": users,
-}, nil)
+	utils.RespondList(w, r, "users", users, utils.NewMeta(page, limit, total))
 }
 
 // GetUser retrieves a single user by ID
@@ -82,7 +183,11 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 	id := params["id"]
 
 	// Get current user from context
-	claims, _ := r.Context().Value("user").(*utils.Claims)
+	claims, ok := r.Context().Value(middleware.UserContextKey).(*utils.Claims)
+	if !ok || claims == nil {
+		utils.RespondUnauthorized(w, "Authentication required")
+		return
+	}
 
 	// Check if user can update (admin or self)
 	if claims.Role != string(models.RoleAdmin) && claims.UserID != id {
@@ -90,25 +195,121 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var updates map[string]interface{}
-	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+	// Only the whitelisted fields are decoded; any other keys are ignored
+	var req UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		utils.RespondBadRequest(w, "Invalid request payload")
 		return
 	}
 
+	// Validate the fields being changed
+	updates := make(map[string]interface{})
+	fields := make(map[string]string)
+	if req.Name != nil {
+		if name := strings.TrimSpace(*req.Name); name == "" {
+			fields["name"] = "Name cannot be empty"
+		} else {
+			updates["name"] = name
+		}
+	}
+	if req.Email != nil {
+		// Store emails normalized like Register does
+		email := normalizeEmail(*req.Email)
+		if err := utils.ValidateEmail(email); err != nil {
+			fields["email"] = "Email " + err.Error()
+		} else {
+			updates["email"] = email
+		}
+	}
+	if req.Password != nil {
+		if err := utils.ValidatePassword(*req.Password); err != nil {
+			fields["password"] = "Password " + err.Error()
+		}
+	}
 	// Don't allow updating role unless admin
-if claims.Role != string(models.RoleAdmin) {
-delete(updates, "role")
-}
+	if req.Role != nil && claims.Role == string(models.RoleAdmin) {
+		if *req.Role != models.RoleAdmin && *req.Role != models.RoleUser {
+			fields["role"] = "Role must be admin or user"
+		} else {
+			updates["role"] = *req.Role
+		}
+	}
+	if len(fields) > 0 {
+		utils.RespondValidationError(w, fields)
+		return
+	}
+
+	db := config.GetDB()
+	var user models.User
+	if err := db.First(&user, "id = ?", id).Error; err != nil {
+		utils.RespondNotFound(w, "User")
+		return
+	}
+
+	// Changing your own email or password needs your current password
+	emailChanged := updates["email"] != nil && updates["email"] != strings.ToLower(user.Email)
+	if claims.UserID == user.ID && (emailChanged || req.Password != nil) {
+		if req.CurrentPassword == "" {
+			fields["current_password"] = "Current password is required to change your email or password"
+		} else if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+			fields["current_password"] = "Current password is incorrect"
+		}
+		if len(fields) > 0 {
+			utils.RespondValidationError(w, fields)
+			return
+		}
+	}
+
+	// The new email must not belong to another account
+	if email, ok := updates["email"].(string); ok && emailChanged {
+		var existing models.User
+		if err := db.Unscoped().Where("LOWER(email) = ? AND id <> ?", email, user.ID).First(&existing).Error; err == nil {
+			utils.RespondError(w, http.StatusConflict, "EMAIL_EXISTS", "Email already registered", nil)
+			return
+		}
+	}
 
-// Hash password if it's being updated
-	if password, ok := updates["password"].(string); ok {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	// Hash password if it's being updated
+	if req.Password != nil {
+		hashedPassword, err := utils.HashPassword(*req.Password)
 		if err != nil {
 			utils.RespondInternalError(w)
 			return
 		}
-		updates["password"] = string(hashedPassword)
+		updates["password"] = hashedPassword
+	}
+
+	if len(updates) > 0 {
+		if err := db.Model(&user).Updates(updates).Error; err != nil {
+			// The unique index still catches a concurrent change to the same email
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				utils.RespondError(w, http.StatusConflict, "EMAIL_EXISTS", "Email already registered", nil)
+				return
+			}
+			utils.RespondInternalError(w)
+			return
+		}
+	}
+
+	user.Password = ""
+	utils.RespondSuccess(w, http.StatusOK, user, nil)
+}
+
+// ImpersonateUser issues a short-lived token that lets an admin act as another
+// user for support. Every impersonation is logged with both user IDs.
+func ImpersonateUser(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	claims, ok := requireClaims(w, r)
+	if !ok {
+		return
+	}
+
+	// Nested impersonation would hide who is really acting
+	if claims.ImpersonatedBy != "" {
+		utils.RespondForbidden(w, "Can't impersonate while impersonating")
+		return
 	}
 
 	db := config.GetDB()
@@ -118,13 +319,25 @@ delete(updates, "role")
 		return
 	}
 
-	if err := db.Model(&user).Updates(updates).Error; err != nil {
+	if user.ID == claims.UserID || user.Role == models.RoleAdmin {
+		utils.RespondForbidden(w, "Admins can't be impersonated")
+		return
+	}
+
+	token, expiresAt, err := utils.GenerateImpersonationJWT(user.ID, user.Email, string(user.Role), claims.UserID)
+	if err != nil {
 		utils.RespondInternalError(w)
 		return
 	}
 
-	user.Password = ""
-	utils.RespondSuccess(w, http.StatusOK, user, nil)
+	audit(r, "user.impersonate", "user", user.ID, "until="+expiresAt.Format(time.RFC3339))
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"user_id":         user.ID,
+		"token":           token,
+		"expires_at":      expiresAt.Unix(),
+		"impersonated_by": claims.UserID,
+	}, nil)
 }
 
 // DeleteUser soft deletes a user (admin only)
@@ -137,6 +350,7 @@ func DeleteUser(w http.ResponseWriter, r *http.Request) {
 		utils.RespondInternalError(w)
 		return
 	}
+	invalidateDashboardStats(r.Context())
 
 	utils.RespondSuccess(w, http.StatusOK, map[string]string{
 "message": "User deleted successfully",
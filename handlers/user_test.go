@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+)
+
+func TestUpdateUserDuplicateEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		wantStatus int
+	}{
+		{"unchanged", "me@example.com", http.StatusOK},
+		{"own email in another case", "ME@example.com", http.StatusOK},
+		{"free email", "new@example.com", http.StatusOK},
+		{"another user's email", "other@example.com", http.StatusConflict},
+		{"another user's email in another case", " Other@Example.com", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			me := createTestUser(t, db, "me@example.com", models.RoleUser)
+			createTestUser(t, db, "other@example.com", models.RoleUser)
+			hashed, err := utils.HashPassword("golf2025")
+			if err != nil {
+				t.Fatal(err)
+			}
+			db.Model(&me).Update("password", hashed)
+
+			r := newJSONRequest(t, http.MethodPut, "/api/users/"+me.ID, map[string]string{"email": tt.email, "current_password": "golf2025"})
+			r = withClaims(mux.SetURLVars(r, map[string]string{"id": me.ID}), me.ID, models.RoleUser)
+			w := httptest.NewRecorder()
+			UpdateUser(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusConflict {
+				if code := errorCode(t, w); code != "EMAIL_EXISTS" {
+					t.Errorf("error code = %q, want EMAIL_EXISTS", code)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateUserWithoutClaims(t *testing.T) {
+	db := setupTestDB(t)
+	user := createTestUser(t, db, "me@example.com", models.RoleUser)
+
+	r := newJSONRequest(t, http.MethodPut, "/api/users/"+user.ID, map[string]string{"name": "New Name"})
+	w := httptest.NewRecorder()
+	UpdateUser(w, mux.SetURLVars(r, map[string]string{"id": user.ID}))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusUnauthorized, w.Body.String())
+	}
+}
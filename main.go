@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"sentul-golf-be/config"
+	"sentul-golf-be/middleware"
 	"sentul-golf-be/models"
 	"sentul-golf-be/routes"
 	"sentul-golf-be/utils"
@@ -16,12 +21,45 @@ func main() {
 	// Load environment variables
 	config.LoadEnv()
 
+	// Refuse to start with missing or insecure settings
+	if err := config.ValidateEnv(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	// Load the token signing secret and lifetimes
+	if err := utils.LoadJWTConfig(); err != nil {
+		log.Fatal("Invalid JWT configuration:", err)
+	}
+
+	// Load the image upload size and type limits
+	if err := utils.LoadImageConfig(); err != nil {
+		log.Fatal("Invalid image configuration:", err)
+	}
+
+	// Load the reverse proxies allowed to report the client IP
+	if err := utils.LoadProxyConfig(); err != nil {
+		log.Fatal("Invalid proxy configuration:", err)
+	}
+
+	// Load the CORS origin allowlist
+	middleware.LoadCORSConfig()
+
 	// Connect to database
 	config.ConnectDB()
 
 	// Connect to Redis for caching
 	config.ConnectRedis()
 
+	// Select the storage backend for uploaded images
+	if err := utils.InitStorage(); err != nil {
+		log.Fatal("Failed to initialize storage:", err)
+	}
+
+	// Select the mail backend for outgoing emails
+	if err := utils.InitMailer(); err != nil {
+		log.Fatal("Failed to initialize mailer:", err)
+	}
+
 	// Create upload directories if they don't exist
 	createUploadDirectories()
 
@@ -32,6 +70,11 @@ func main() {
 		&models.News{},
 		&models.Event{},
 		&models.Hole{},
+		&models.Amenity{},
+		&models.Tag{},
+		&models.Image{},
+		&models.SlugHistory{},
+		&models.AuditLog{},
 	); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
@@ -39,6 +82,27 @@ func main() {
 	// Create default admin user if not exists
 	createDefaultAdmin()
 
+	// Periodically write view counts collected in Redis to the database
+	utils.StartViewCountFlusher(viewFlushInterval())
+
+	// Publish news and events whose scheduled publish time has passed
+	utils.StartScheduledPublisher(publishInterval())
+
+	// Write buffered audit entries to the database in batches
+	utils.StartAuditFlusher(auditFlushInterval())
+
+	// Optionally delete uploaded files nothing references any more
+	if config.GetEnv("UPLOAD_SWEEP_ON_STARTUP", "false") == "true" {
+		go func() {
+			report, err := utils.SweepOrphanUploads(context.Background(), utils.OrphanUploadMinAge(), false)
+			if err != nil {
+				log.Printf("Warning: Failed to sweep orphaned uploads: %v", err)
+				return
+			}
+			log.Printf("Swept %d orphaned upload(s) of %d scanned", report.Deleted, report.Scanned)
+		}()
+	}
+
 	// Setup routes
 	router := routes.SetupRoutes()
 
@@ -46,8 +110,53 @@ func main() {
 	port := config.GetEnv("PORT", "8080")
 	addr := fmt.Sprintf(":%s", port)
 	
-	log.Printf("Server starting on http://localhost%s", addr)
-	log.Fatal(http.ListenAndServe(addr, router))
+	server := &http.Server{Addr: addr, Handler: router}
+
+	// Stop gracefully on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		log.Printf("Server starting on http://localhost%s", addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+	<-ctx.Done()
+
+	// Let in-flight requests finish, then write what is still buffered
+	log.Println("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Warning: Server shutdown: %v", err)
+	}
+	if err := utils.FlushAuditLogs(); err != nil {
+		log.Printf("Warning: Failed to flush audit logs: %v", err)
+	}
+}
+
+// viewFlushInterval returns how often view counts are flushed to the database
+func viewFlushInterval() time.Duration {
+	if d, err := time.ParseDuration(config.GetEnv("VIEW_FLUSH_INTERVAL", "")); err == nil && d > 0 {
+		return d
+	}
+	return time.Minute
+}
+
+// publishInterval returns the longest the scheduled publisher sleeps between checks
+func publishInterval() time.Duration {
+	if d, err := time.ParseDuration(config.GetEnv("PUBLISH_INTERVAL", "")); err == nil && d > 0 {
+		return d
+	}
+	return time.Minute
+}
+
+// auditFlushInterval returns how often buffered audit entries are written to the database
+func auditFlushInterval() time.Duration {
+	if d, err := time.ParseDuration(config.GetEnv("AUDIT_FLUSH_INTERVAL", "")); err == nil && d > 0 {
+		return d
+	}
+	return 5 * time.Second
 }
 
 func createUploadDirectories() {
@@ -56,6 +165,7 @@ func createUploadDirectories() {
 		"./uploads/news",
 		"./uploads/events",
 		"./uploads/holes",
+		"./uploads/amenities",
 		"./uploads/content", // For inline images in rich text editor
 	}
 
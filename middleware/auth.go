@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"context"
+	"log"
 	"net/http"
-	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"sentul-golf-be/config"
 	"sentul-golf-be/models"
@@ -38,12 +40,18 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		}
 
 		token := parts[1]
-		claims, err := utils.ValidateJWT(token, os.Getenv("JWT_SECRET"))
+		claims, err := utils.ValidateJWT(token)
 		if err != nil {
 			utils.RespondUnauthorized(w, "Invalid or expired token")
 			return
 		}
 
+		// Reject tokens revoked by logout
+		if utils.IsTokenRevoked(r.Context(), claims.ID) {
+			utils.RespondUnauthorized(w, "Token has been revoked")
+			return
+		}
+
 		// Verify user still exists in database (not deleted)
 		db := config.GetDB()
 		var user models.User
@@ -52,22 +60,56 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// Impersonation tokens are only valid while the issuing admin still is one
+		if claims.ImpersonatedBy != "" {
+			var admin models.User
+			if err := db.Where("id = ? AND role = ?", claims.ImpersonatedBy, models.RoleAdmin).First(&admin).Error; err != nil {
+				utils.RespondUnauthorized(w, "Impersonating admin not found")
+				return
+			}
+			w.Header().Set("X-Impersonated-By", claims.ImpersonatedBy)
+		}
+
 		// Add user info to context
 		ctx := context.WithValue(r.Context(), UserContextKey, claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-// RequireRole checks if user has required role
+// OptionalAuth attaches the user claims to the context when a valid token is
+// present, but lets anonymous requests through unchanged
+func OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.Header.Get("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := utils.ValidateJWT(parts[1]); err == nil && !utils.IsTokenRevoked(r.Context(), claims.ID) {
+				// Only trust the token if the user still exists
+				var user models.User
+				if err := config.GetDB().Where("id = ?", claims.UserID).First(&user).Error; err == nil {
+					r = r.WithContext(context.WithValue(r.Context(), UserContextKey, claims))
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole checks if user has required role. It must run after
+// AuthMiddleware: authenticated users without the role get 403.
 func RequireRole(role models.Role) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// AuthMiddleware has already answered 401 for unauthenticated
+			// requests, so missing claims mean the route skipped it
 			claims, ok := r.Context().Value(UserContextKey).(*utils.Claims)
 			if !ok {
-				utils.RespondUnauthorized(w, "Unauthorized")
+				log.Printf("RequireRole on %s %s without AuthMiddleware", r.Method, r.URL.Path)
+				utils.RespondInternalError(w)
 				return
 			}
 
+			// Authenticated but not allowed
 			if claims.Role != string(role) && claims.Role != string(models.RoleAdmin) {
 				utils.RespondForbidden(w, "Insufficient permissions")
 				return
@@ -92,39 +134,129 @@ func RequireAdmin(next http.Handler) http.Handler {
 	})
 }
 
+// defaultCORSOrigins are the local frontend origins allowed when
+// CORS_ALLOWED_ORIGINS is not set
+var defaultCORSOrigins = []string{
+	"http://localhost:3000",
+	"http://localhost:3001",
+	"http://127.0.0.1:3000",
+}
+
+// corsOrigins is the origin allowlist, and corsAllowAll is set when it contains "*"
+var corsOrigins, corsAllowAll = parseCORSOrigins(defaultCORSOrigins)
+
+// defaultCORSMethods are the methods advertised when CORS_ALLOWED_METHODS is not set
+var defaultCORSMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+
+var (
+	// corsMethods are the methods advertised to preflight requests
+	corsMethods = defaultCORSMethods
+	// corsMaxAge is how long browsers may cache a preflight response, in seconds
+	corsMaxAge = "86400"
+	// corsRouteMethods returns the methods the route of a request supports,
+	// when the routes are known (see SetCORSRouteMethods)
+	corsRouteMethods func(r *http.Request) []string
+)
+
+// LoadCORSConfig reads the comma-separated CORS_ALLOWED_ORIGINS allowlist.
+// A "*" entry allows every origin, without credentials (meant for development).
+func LoadCORSConfig() {
+	var origins []string
+	for _, origin := range strings.Split(config.GetEnv("CORS_ALLOWED_ORIGINS", ""), ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	if len(origins) == 0 {
+		origins = defaultCORSOrigins
+	}
+	corsOrigins, corsAllowAll = parseCORSOrigins(origins)
+
+	// Advertised methods, e.g. "GET, POST, PUT, DELETE, OPTIONS"
+	var methods []string
+	for _, method := range strings.Split(config.GetEnv("CORS_ALLOWED_METHODS", ""), ",") {
+		if method = strings.ToUpper(strings.TrimSpace(method)); method != "" {
+			methods = append(methods, method)
+		}
+	}
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	corsMethods = methods
+
+	// Preflight cache lifetime, e.g. "24h" in production or "0s" while routes change in development
+	corsMaxAge = "86400"
+	if value := config.GetEnv("CORS_MAX_AGE", ""); value != "" {
+		maxAge, err := time.ParseDuration(value)
+		if err != nil || maxAge < 0 {
+			log.Printf("Warning: Invalid CORS_MAX_AGE %q, using 24h", value)
+		} else {
+			corsMaxAge = strconv.Itoa(int(maxAge.Seconds()))
+		}
+	}
+}
+
+// SetCORSRouteMethods lets preflight responses advertise only the methods
+// the requested route supports. fn returns those methods for a request.
+func SetCORSRouteMethods(fn func(r *http.Request) []string) {
+	corsRouteMethods = fn
+}
+
+// allowedMethods returns the configured methods, narrowed for preflight
+// requests to those the requested route supports
+func allowedMethods(r *http.Request) string {
+	if r.Method != http.MethodOptions || corsRouteMethods == nil {
+		return strings.Join(corsMethods, ", ")
+	}
+
+	supported := make(map[string]bool)
+	for _, method := range corsRouteMethods(r) {
+		supported[method] = true
+	}
+	methods := make([]string, 0, len(corsMethods))
+	for _, method := range corsMethods {
+		if supported[method] || method == http.MethodOptions {
+			methods = append(methods, method)
+		}
+	}
+	return strings.Join(methods, ", ")
+}
+
+// parseCORSOrigins turns an origin list into a lookup set and reports whether it contains "*"
+func parseCORSOrigins(origins []string) (map[string]bool, bool) {
+	set := make(map[string]bool, len(origins))
+	allowAll := false
+	for _, origin := range origins {
+		if origin == "*" {
+			allowAll = true
+			continue
+		}
+		set[origin] = true
+	}
+	return set, allowAll
+}
+
 // CORSMiddleware handles CORS
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		origin := r.Header.Get("Origin")
 		
-		// Allow localhost:3000 and any other origins (you can restrict this later)
-		allowedOrigins := []string{
-			"http://localhost:3000",
-			"http://localhost:3001",
-			"http://127.0.0.1:3000",
-		}
-		
-		// Check if origin is in allowed list
-		isAllowed := false
-		for _, allowedOrigin := range allowedOrigins {
-			if origin == allowedOrigin {
-				isAllowed = true
-				break
-			}
-		}
-		
-		// Set CORS headers
-		if isAllowed {
+		// Responses differ per origin, so shared caches must key on it
+		w.Header().Add("Vary", "Origin")
+
+		// Set CORS headers. Credentials are only allowed for listed origins;
+		// other origins get no Allow-Origin header and are blocked by the browser.
+		if origin != "" && corsOrigins[origin] {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
-		} else {
-			// Fallback to allow all origins (you can change this to deny if preferred)
+		} else if corsAllowAll {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 		}
 		
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Max-Age", "86400") // Cache preflight for 24 hours
+		w.Header().Set("Access-Control-Allow-Methods", allowedMethods(r))
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Response-Style, X-Request-ID, If-None-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Total-Count, Link, X-Impersonated-By, Retry-After, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, ETag")
+		w.Header().Set("Access-Control-Max-Age", corsMaxAge)
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
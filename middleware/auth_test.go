@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupAuthTest loads a JWT secret and points config.DB at an in-memory
+// database with an admin and a regular user, returning a token for each
+func setupAuthTest(t *testing.T) (adminToken, userToken string) {
+	t.Helper()
+
+	t.Setenv("JWT_SECRET", strings.Repeat("s", config.MinJWTSecretLength))
+	if err := utils.LoadJWTConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	dsn := "file:" + strings.NewReplacer("/", "_", " ", "_").Replace(t.Name()) + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.User{}); err != nil {
+		t.Fatal(err)
+	}
+	previous := config.DB
+	config.DB = db
+	t.Cleanup(func() { config.DB = previous })
+
+	token := func(email string, role models.Role) string {
+		user := models.User{Name: email, Email: email, Password: "hashed", Role: role}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatal(err)
+		}
+		token, _, err := utils.GenerateJWT(user.ID, user.Email, string(role))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	return token("admin@example.com", models.RoleAdmin), token("user@example.com", models.RoleUser)
+}
+
+func TestRequireAdminStatuses(t *testing.T) {
+	adminToken, userToken := setupAuthTest(t)
+
+	tests := []struct {
+		name          string
+		authorization string
+		skipAuth      bool // Route the request around AuthMiddleware
+		wantStatus    int
+	}{
+		{name: "no token", wantStatus: http.StatusUnauthorized},
+		{name: "malformed header", authorization: "Token " + adminToken, wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", authorization: "Bearer not-a-token", wantStatus: http.StatusUnauthorized},
+		{name: "user without the role", authorization: "Bearer " + userToken, wantStatus: http.StatusForbidden},
+		{name: "admin", authorization: "Bearer " + adminToken, wantStatus: http.StatusOK},
+		{name: "route without AuthMiddleware", authorization: "Bearer " + adminToken, skipAuth: true, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handler http.Handler = RequireAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			if !tt.skipAuth {
+				handler = AuthMiddleware(handler)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// loadTestCORSConfig loads the CORS settings for one test and restores the
+// previous ones afterwards
+func loadTestCORSConfig(t *testing.T, origins string) {
+	t.Helper()
+
+	previousOrigins, previousAllowAll := corsOrigins, corsAllowAll
+	previousMethods, previousMaxAge := corsMethods, corsMaxAge
+	t.Cleanup(func() {
+		corsOrigins, corsAllowAll = previousOrigins, previousAllowAll
+		corsMethods, corsMaxAge = previousMethods, previousMaxAge
+	})
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", origins)
+	LoadCORSConfig()
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		origins         string // CORS_ALLOWED_ORIGINS
+		origin          string // Origin request header
+		method          string
+		wantOrigin      string
+		wantCredentials bool
+		wantNext        bool
+	}{
+		{
+			name:            "listed origin",
+			origins:         "https://sentulgolf.example, https://admin.sentulgolf.example/",
+			origin:          "https://admin.sentulgolf.example",
+			method:          http.MethodGet,
+			wantOrigin:      "https://admin.sentulgolf.example",
+			wantCredentials: true,
+			wantNext:        true,
+		},
+		{
+			name:     "unlisted origin",
+			origins:  "https://sentulgolf.example",
+			origin:   "https://evil.example",
+			method:   http.MethodGet,
+			wantNext: true,
+		},
+		{
+			name:     "no origin",
+			origins:  "https://sentulgolf.example",
+			method:   http.MethodGet,
+			wantNext: true,
+		},
+		{
+			name:            "default dev origins",
+			origin:          "http://localhost:3000",
+			method:          http.MethodGet,
+			wantOrigin:      "http://localhost:3000",
+			wantCredentials: true,
+			wantNext:        true,
+		},
+		{
+			name:       "wildcard",
+			origins:    "*",
+			origin:     "https://anywhere.example",
+			method:     http.MethodGet,
+			wantOrigin: "*",
+			wantNext:   true,
+		},
+		{
+			name:            "listed origin wins over wildcard",
+			origins:         "*,https://sentulgolf.example",
+			origin:          "https://sentulgolf.example",
+			method:          http.MethodGet,
+			wantOrigin:      "https://sentulgolf.example",
+			wantCredentials: true,
+			wantNext:        true,
+		},
+		{
+			name:            "preflight",
+			origins:         "https://sentulgolf.example",
+			origin:          "https://sentulgolf.example",
+			method:          http.MethodOptions,
+			wantOrigin:      "https://sentulgolf.example",
+			wantCredentials: true,
+		},
+		{
+			name:    "preflight from unlisted origin",
+			origins: "https://sentulgolf.example",
+			origin:  "https://evil.example",
+			method:  http.MethodOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadTestCORSConfig(t, tt.origins)
+
+			called := false
+			handler := CORSMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+			r := httptest.NewRequest(tt.method, "/api/news", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials") == "true"; got != tt.wantCredentials {
+				t.Errorf("credentials allowed = %v, want %v", got, tt.wantCredentials)
+			}
+			if got := w.Header().Get("Vary"); got != "Origin" {
+				t.Errorf("Vary = %q, want Origin", got)
+			}
+			if called != tt.wantNext {
+				t.Errorf("next handler called = %v, want %v", called, tt.wantNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/utils"
+)
+
+// writeRateLimit returns how many write requests a user may send per minute,
+// from RATE_LIMIT_PER_MINUTE (default 60)
+func writeRateLimit() int {
+	if l, err := strconv.Atoi(config.GetEnv("RATE_LIMIT_PER_MINUTE", "")); err == nil && l > 0 {
+		return l
+	}
+	return 60
+}
+
+// isWriteMethod reports whether a request method changes data
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// RateLimitWrites throttles POST, PUT, PATCH and DELETE requests per
+// authenticated user (per IP without a user) in one-minute windows counted in
+// Redis. Reads pass through untouched, and so does everything when Redis is
+// unavailable. Must run after AuthMiddleware.
+func RateLimitWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isWriteMethod(r.Method) || !utils.IsRedisAvailable() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := "writes:ip:" + utils.ClientIP(r)
+		if claims, ok := r.Context().Value(UserContextKey).(*utils.Claims); ok {
+			key = "writes:user:" + claims.UserID
+		}
+
+		limit := utils.HitRateLimit(r.Context(), key, writeRateLimit(), time.Minute)
+		utils.SetRateLimitHeaders(w, limit)
+
+		if !limit.Allowed {
+			utils.RespondError(w, http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests. Please try again later", nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
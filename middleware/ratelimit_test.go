@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/utils"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRateLimitWrites(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		hits          int
+		wantStatus    int
+		wantRemaining string // "" when no headers are sent
+	}{
+		{"reads pass through", http.MethodGet, 3, http.StatusOK, ""},
+		{"write within the limit", http.MethodPost, 2, http.StatusOK, "0"},
+		{"write over the limit", http.MethodDelete, 3, http.StatusTooManyRequests, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := miniredis.RunT(t)
+			previous := config.RedisClient
+			config.RedisClient = redis.NewClient(&redis.Options{Addr: server.Addr()})
+			t.Cleanup(func() { config.RedisClient = previous })
+			t.Setenv("RATE_LIMIT_PER_MINUTE", "2")
+
+			handler := RateLimitWrites(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			var w *httptest.ResponseRecorder
+			for i := 0; i < tt.hits; i++ {
+				w = httptest.NewRecorder()
+				handler.ServeHTTP(w, httptest.NewRequest(tt.method, "/api/news", nil))
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("RateLimit-Remaining"); got != tt.wantRemaining {
+				t.Errorf("RateLimit-Remaining = %q, want %q", got, tt.wantRemaining)
+			}
+			wantRetryAfter := tt.wantStatus == http.StatusTooManyRequests
+			if got := w.Header().Get("Retry-After") != ""; got != wantRetryAfter {
+				t.Errorf("Retry-After set = %v, want %v", got, wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestRateLimitWritesKeysByClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remote     string
+		forwarded  [2]string // X-Forwarded-For of the first and second request
+		wantStatus int       // of the second request
+	}{
+		{"clients behind a trusted proxy are limited apart", "10.0.0.1:4000", [2]string{"203.0.113.1", "203.0.113.2"}, http.StatusOK},
+		{"same client behind a trusted proxy shares a limit", "10.0.0.1:4000", [2]string{"203.0.113.1", "203.0.113.1"}, http.StatusTooManyRequests},
+		{"untrusted peer can't dodge the limit with headers", "198.51.100.9:4000", [2]string{"203.0.113.1", "203.0.113.2"}, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := miniredis.RunT(t)
+			previous := config.RedisClient
+			config.RedisClient = redis.NewClient(&redis.Options{Addr: server.Addr()})
+			t.Cleanup(func() { config.RedisClient = previous })
+			t.Setenv("RATE_LIMIT_PER_MINUTE", "1")
+			// Registered first so it reloads once TRUSTED_PROXIES is restored
+			t.Cleanup(func() { utils.LoadProxyConfig() })
+			t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8")
+			if err := utils.LoadProxyConfig(); err != nil {
+				t.Fatalf("LoadProxyConfig() error = %v", err)
+			}
+
+			handler := RateLimitWrites(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			var w *httptest.ResponseRecorder
+			for _, forwarded := range tt.forwarded {
+				r := httptest.NewRequest(http.MethodPost, "/api/news", nil)
+				r.RemoteAddr = tt.remote
+				r.Header.Set("X-Forwarded-For", forwarded)
+				w = httptest.NewRecorder()
+				handler.ServeHTTP(w, r)
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/utils"
+)
+
+// uploadConcurrencyLimit returns how many multipart uploads one client IP may
+// have in flight at once, from UPLOAD_CONCURRENCY_PER_IP (default 3, 0 disables)
+func uploadConcurrencyLimit() int {
+	if l, err := strconv.Atoi(config.GetEnv("UPLOAD_CONCURRENCY_PER_IP", "")); err == nil && l >= 0 {
+		return l
+	}
+	return 3
+}
+
+// uploadSlots counts the in-flight uploads of each client IP
+var uploadSlots = struct {
+	sync.Mutex
+	inFlight map[string]int
+}{inFlight: make(map[string]int)}
+
+// acquireUploadSlot takes one of ip's upload slots, reporting false when all
+// limit slots are in use
+func acquireUploadSlot(ip string, limit int) bool {
+	uploadSlots.Lock()
+	defer uploadSlots.Unlock()
+	if uploadSlots.inFlight[ip] >= limit {
+		return false
+	}
+	uploadSlots.inFlight[ip]++
+	return true
+}
+
+// releaseUploadSlot gives back a slot taken by acquireUploadSlot
+func releaseUploadSlot(ip string) {
+	uploadSlots.Lock()
+	defer uploadSlots.Unlock()
+	if uploadSlots.inFlight[ip] <= 1 {
+		delete(uploadSlots.inFlight, ip)
+		return
+	}
+	uploadSlots.inFlight[ip]--
+}
+
+// isMultipartUpload reports whether r carries a multipart/form-data body
+func isMultipartUpload(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// LimitConcurrentUploads caps the multipart uploads a client IP can have in
+// flight at once, so parallel uploads can't saturate disk I/O and memory.
+// Unlike RateLimitWrites it counts requests still being handled rather than
+// requests per minute. Slots are held per server instance, in memory.
+func LimitConcurrentUploads(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := uploadConcurrencyLimit()
+		if limit == 0 || !isMultipartUpload(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip := utils.ClientIP(r)
+		if !acquireUploadSlot(ip, limit) {
+			w.Header().Set("Retry-After", "1")
+			utils.RespondError(w, http.StatusTooManyRequests, "TOO_MANY_UPLOADS", "Too many uploads in progress. Please wait for one to finish", nil)
+			return
+		}
+		defer releaseUploadSlot(ip)
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,15 @@
+-- Migration: Add published_at to news and events
+-- Date: 2026-10-16
+-- Description: Record when an item went live in published_at and keep
+-- publish_at for pending schedules only. AutoMigrate adds the columns; this
+-- moves the go-live times stored in publish_at of published rows over.
+
+-- Step 1: Add the columns (AutoMigrate does the same on startup)
+ALTER TABLE news ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
+
+-- Step 2: Move the go-live time of published rows out of publish_at
+UPDATE news SET published_at = publish_at, publish_at = NULL
+WHERE published = TRUE AND publish_at IS NOT NULL;
+UPDATE events SET published_at = publish_at, publish_at = NULL
+WHERE published = TRUE AND publish_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Migration: Add stroke_index to holes
+-- Date: 2026-10-16
+-- Description: Optional handicap stroke index (1 is the hardest hole) used to
+-- rank holes by difficulty. AutoMigrate adds the column on startup as well.
+
+ALTER TABLE holes ADD COLUMN IF NOT EXISTS stroke_index INT;
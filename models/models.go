@@ -38,20 +38,28 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 }
 
 type News struct {
-	ID         string         `gorm:"primaryKey;type:varchar(25)" json:"id"`
-	Title      string         `gorm:"not null" json:"title"`
-	Content    string         `gorm:"type:text;not null" json:"content"`
-	Excerpt    string         `gorm:"type:varchar(200)" json:"excerpt"` // Plain text excerpt
-	Slug       string         `gorm:"uniqueIndex;not null" json:"slug"`
-	Published  bool           `gorm:"default:false" json:"published"`
-	ImageURL   string         `json:"image_url"`
-	AuthorID   string         `gorm:"type:varchar(25);not null" json:"author_id"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            string         `gorm:"primaryKey;type:varchar(25)" json:"id"`
+	Title         string         `gorm:"not null" json:"title"`
+	Content       string         `gorm:"type:text;not null" json:"content"`
+	Excerpt       string         `gorm:"type:varchar(200)" json:"excerpt"`    // Plain text excerpt
+	ExcerptManual bool           `gorm:"default:false" json:"excerpt_manual"` // Excerpt was written by an editor, not generated
+	Slug          string         `gorm:"uniqueIndex;not null" json:"slug"`
+	Published     bool           `gorm:"default:false" json:"published"`
+	Featured      bool           `gorm:"not null;default:false" json:"featured"` // Pinned to the top of lists
+	ImageURL      string         `json:"image_url"`
+	CanonicalURL  string         `gorm:"type:varchar(2048)" json:"canonical_url"` // Original URL of syndicated content, for rel="canonical"
+	AuthorID      string         `gorm:"type:varchar(25);not null" json:"author_id"`
+	ViewCount     int64          `gorm:"not null;default:0" json:"view_count"`
+	PublishAt     *time.Time     `json:"publish_at"`   // Publish automatically at this time; cleared once published
+	PublishedAt   *time.Time     `json:"published_at"` // When the item last went live
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
-	Author User `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Author User    `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Tags   []Tag   `gorm:"many2many:news_tags;" json:"tags,omitempty"`
+	Images []Image `gorm:"polymorphic:Parent;polymorphicValue:news" json:"images,omitempty"`
 }
 
 // BeforeCreate hook to generate CUID
@@ -63,22 +71,33 @@ func (n *News) BeforeCreate(tx *gorm.DB) error {
 }
 
 type Event struct {
-	ID         string         `gorm:"primaryKey;type:varchar(25)" json:"id"`
-	Title      string         `gorm:"not null" json:"title"`
-	Content    string         `gorm:"type:text;not null" json:"content"`
-	Excerpt    string         `gorm:"type:varchar(200)" json:"excerpt"` // Plain text excerpt
-	Slug       string         `gorm:"uniqueIndex;not null" json:"slug"`
-	Published  bool           `gorm:"default:false" json:"published"`
-	ImageURL   string         `json:"image_url"`
-	AuthorID   string         `gorm:"type:varchar(25);not null" json:"author_id"`
-	EventStart *time.Time     `json:"event_start"` // Start date & time of event
-	EventEnd   *time.Time     `json:"event_end"`   // End date & time of event
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            string         `gorm:"primaryKey;type:varchar(25)" json:"id"`
+	Title         string         `gorm:"not null" json:"title"`
+	Content       string         `gorm:"type:text;not null" json:"content"`
+	Excerpt       string         `gorm:"type:varchar(200)" json:"excerpt"`    // Plain text excerpt
+	ExcerptManual bool           `gorm:"default:false" json:"excerpt_manual"` // Excerpt was written by an editor, not generated
+	Slug          string         `gorm:"uniqueIndex;not null" json:"slug"`
+	Published     bool           `gorm:"default:false" json:"published"`
+	Featured      bool           `gorm:"not null;default:false" json:"featured"` // Pinned to the top of lists
+	ImageURL      string         `json:"image_url"`
+	CanonicalURL  string         `gorm:"type:varchar(2048)" json:"canonical_url"` // Original URL of syndicated content, for rel="canonical"
+	AuthorID      string         `gorm:"type:varchar(25);not null" json:"author_id"`
+	ViewCount     int64          `gorm:"not null;default:0" json:"view_count"`
+	PublishAt     *time.Time     `json:"publish_at"`                        // Publish automatically at this time; cleared once published
+	PublishedAt   *time.Time     `json:"published_at"`                      // When the item last went live
+	EventStart    *time.Time     `json:"event_start"`                       // Start date & time of event
+	EventEnd      *time.Time     `json:"event_end"`                         // End date & time of event
+	Location      string         `gorm:"type:varchar(255)" json:"location"` // Where on the property the event takes place
+	Latitude      *float64       `json:"latitude"`                          // Optional map pin latitude
+	Longitude     *float64       `json:"longitude"`                         // Optional map pin longitude
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations
-	Author User `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Author User    `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Tags   []Tag   `gorm:"many2many:event_tags;" json:"tags,omitempty"`
+	Images []Image `gorm:"polymorphic:Parent;polymorphicValue:event" json:"images,omitempty"`
 }
 
 // BeforeCreate hook to generate CUID
@@ -96,6 +115,7 @@ type Hole struct {
 	Description string         `gorm:"type:text" json:"description"`
 	Par         int            `gorm:"default:0" json:"par"`      // Par value for this hole
 	Distance    int            `gorm:"default:0" json:"distance"` // Distance in meters
+	StrokeIndex *int           `json:"stroke_index"`              // Handicap stroke index, 1 is the hardest hole; optional
 	ImageURL    string         `json:"image_url"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
@@ -110,4 +130,96 @@ func (h *Hole) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// Amenity is a club facility shown on the public site, e.g. the driving range
+type Amenity struct {
+	ID          string         `gorm:"primaryKey;type:varchar(25)" json:"id"`
+	Name        string         `gorm:"not null" json:"name"`
+	Description string         `gorm:"type:text" json:"description"`
+	Icon        string         `json:"icon"` // Icon name used by the frontend
+	ImageURL    string         `json:"image_url"`
+	SortOrder   int            `gorm:"default:0" json:"sort_order"` // Display order, ascending
+	Active      bool           `gorm:"default:true" json:"active"`  // Inactive amenities are hidden from the public list
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// BeforeCreate hook to generate CUID
+func (a *Amenity) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = cuid.New()
+	}
+	return nil
+}
+
+
 
+type Tag struct {
+	ID        string    `gorm:"primaryKey;type:varchar(25)" json:"id"`
+	Name      string    `gorm:"not null" json:"name"`
+	Slug      string    `gorm:"uniqueIndex;not null" json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook to generate CUID
+func (t *Tag) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = cuid.New()
+	}
+	return nil
+}
+
+// Image is a gallery image of a news article or event
+type Image struct {
+	ID         string    `gorm:"primaryKey;type:varchar(25)" json:"id"`
+	ParentType string    `gorm:"type:varchar(10);not null;index:idx_images_parent" json:"parent_type"` // "news" or "event"
+	ParentID   string    `gorm:"type:varchar(25);not null;index:idx_images_parent" json:"parent_id"`
+	URL        string    `gorm:"not null" json:"url"`
+	OrderIndex int       `gorm:"not null;default:0" json:"order_index"` // Position in the gallery
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook to generate CUID
+func (i *Image) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = cuid.New()
+	}
+	return nil
+}
+
+// SlugHistory records a slug a news article or event used before it changed
+type SlugHistory struct {
+	ID         string    `gorm:"primaryKey;type:varchar(25)" json:"id"`
+	EntityType string    `gorm:"type:varchar(10);not null;index:idx_slug_histories_entity" json:"entity_type"` // "news" or "event"
+	EntityID   string    `gorm:"type:varchar(25);not null;index:idx_slug_histories_entity" json:"entity_id"`
+	Slug       string    `gorm:"not null;index" json:"slug"`
+	CreatedAt  time.Time `json:"created_at"` // When the slug was replaced
+}
+
+// BeforeCreate hook to generate CUID
+func (h *SlugHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == "" {
+		h.ID = cuid.New()
+	}
+	return nil
+}
+
+// AuditLog records an administrative action
+type AuditLog struct {
+	ID         string    `gorm:"primaryKey;type:varchar(25)" json:"id"`
+	ActorID    string    `gorm:"type:varchar(25);index" json:"actor_id"` // User who performed the action
+	Action     string    `gorm:"type:varchar(50);not null;index" json:"action"`
+	TargetType string    `gorm:"type:varchar(20)" json:"target_type"`
+	TargetID   string    `gorm:"type:varchar(25);index" json:"target_id"`
+	Details    string    `gorm:"type:text" json:"details"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
+// BeforeCreate hook to generate CUID
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = cuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,366 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/middleware"
+	"sentul-golf-be/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// Who may call the routes of a subrouter
+const (
+	accessPublic = ""
+	accessUser   = "user"
+	accessAdmin  = "admin"
+)
+
+// routerAccess records the access level of each subrouter guarded by
+// requireAuth or requireAdmin, so the OpenAPI document can't drift from the
+// middleware actually applied
+var routerAccess = map[*mux.Router]string{}
+
+// requireAuth guards a subrouter with AuthMiddleware
+func requireAuth(router *mux.Router) {
+	router.Use(middleware.AuthMiddleware)
+	routerAccess[router] = accessUser
+}
+
+// requireAdmin guards a subrouter of an authenticated one with RequireAdmin
+func requireAdmin(router *mux.Router) {
+	router.Use(middleware.RequireAdmin)
+	routerAccess[router] = accessAdmin
+}
+
+// handlerPackage prefixes the runtime names of the handlers package's functions
+const handlerPackage = "sentul-golf-be/handlers."
+
+// handlerName returns the name of the handlers package function serving a
+// route, e.g. "GetNews", or "" for anything else (static files, the docs)
+func handlerName(handler http.Handler) string {
+	fn, ok := handler.(http.HandlerFunc)
+	if !ok {
+		return ""
+	}
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	if !strings.HasPrefix(name, handlerPackage) {
+		return ""
+	}
+	return strings.TrimPrefix(name, handlerPackage)
+}
+
+// pathParamPattern matches a mux path variable with an optional pattern
+var pathParamPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]+)?\}`)
+
+// schemaBuilder turns Go types into OpenAPI schemas. Named structs become
+// shared components referenced by $ref.
+type schemaBuilder struct {
+	components map[string]interface{}
+}
+
+// schemaOf returns the schema of values of type t as encoding/json writes them
+func (b *schemaBuilder) schemaOf(t reflect.Type) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+	if t.Kind() == reflect.Pointer {
+		schema := b.schemaOf(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.objectSchema(t)
+		}
+		// Component names are capitalized, unexported types included
+		name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+		ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+		if _, seen := b.components[name]; !seen {
+			// Register first so self-referencing types terminate
+			b.components[name] = nil
+			b.components[name] = b.objectSchema(t)
+		}
+		return ref
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// objectSchema lists the JSON properties of a struct, flattening embedded structs
+func (b *schemaBuilder) objectSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name := strings.Split(tag, ",")[0]
+			if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+				collect(field.Type)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = b.schemaOf(field.Type)
+		}
+	}
+	collect(t)
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+// successSchema wraps data in the {"status","data","meta"} success envelope
+func successSchema(data map[string]interface{}, withMeta bool) map[string]interface{} {
+	properties := map[string]interface{}{
+		"status": map[string]interface{}{"type": "string", "example": "success"},
+		"data":   data,
+	}
+	if withMeta {
+		properties["meta"] = map[string]interface{}{"$ref": "#/components/schemas/Meta"}
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+// jsonContent is a response or request body of the given schema
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+// errorResponse is a response described by the ErrorResponse schema
+func errorResponse(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content":     jsonContent(map[string]interface{}{"$ref": "#/components/schemas/ErrorResponse"}),
+	}
+}
+
+// operation builds the OpenAPI operation of one route
+func (b *schemaBuilder) operation(name, path, access string) map[string]interface{} {
+	doc, documented := operationDocs[name]
+	if !documented {
+		doc = operationDoc{summary: name}
+	}
+
+	op := map[string]interface{}{"operationId": name, "summary": doc.summary}
+	if tag := strings.Split(strings.TrimPrefix(path, "/api/"), "/")[0]; tag != "" {
+		op["tags"] = []string{tag}
+	}
+
+	// Path and query parameters
+	var parameters []interface{}
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		parameters = append(parameters, map[string]interface{}{
+			"name": match[1], "in": "path", "required": true,
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, query := range doc.query {
+		parameters = append(parameters, map[string]interface{}{
+			"name": query, "in": "query",
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	if parameters != nil {
+		op["parameters"] = parameters
+	}
+
+	// Request body: JSON or multipart form fields, where "image" is the file
+	switch {
+	case doc.request != nil:
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(b.schemaOf(reflect.TypeOf(doc.request))),
+		}
+	case doc.form != nil:
+		properties := map[string]interface{}{}
+		for _, field := range doc.form {
+			if field == "image" {
+				properties[field] = map[string]interface{}{"type": "string", "format": "binary"}
+			} else {
+				properties[field] = map[string]interface{}{"type": "string"}
+			}
+		}
+		op["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"multipart/form-data": map[string]interface{}{
+					"schema": map[string]interface{}{"type": "object", "properties": properties},
+				},
+			},
+		}
+	}
+
+	// Success response
+	status := "200"
+	if doc.created {
+		status = "201"
+	}
+	success := map[string]interface{}{"description": "Success"}
+	switch {
+	case doc.produces != "":
+		success["content"] = map[string]interface{}{
+			doc.produces: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+		}
+	case doc.list != nil:
+		items := map[string]interface{}{"type": "array", "items": b.schemaOf(reflect.TypeOf(doc.list))}
+		data := items
+		if doc.listKey != "" {
+			data = map[string]interface{}{"type": "object", "properties": map[string]interface{}{doc.listKey: items}}
+		}
+		success["content"] = jsonContent(successSchema(data, true))
+	default:
+		success["content"] = jsonContent(successSchema(b.schemaOf(reflect.TypeOf(doc.response)), false))
+	}
+	responses := map[string]interface{}{status: success, "default": errorResponse("Error")}
+
+	// Authentication
+	switch access {
+	case accessUser, accessAdmin:
+		op["security"] = []interface{}{map[string]interface{}{"bearerAuth": []string{}}}
+		responses["401"] = errorResponse("Missing or invalid token")
+		if access == accessAdmin {
+			op["description"] = "Admins only."
+			responses["403"] = errorResponse("Not an admin")
+		}
+	}
+	op["responses"] = responses
+
+	return op
+}
+
+// buildOpenAPI describes every handlers route registered on router
+func buildOpenAPI(router *mux.Router) ([]byte, error) {
+	b := &schemaBuilder{components: map[string]interface{}{}}
+	b.schemaOf(reflect.TypeOf(utils.ErrorResponse{}))
+	b.schemaOf(reflect.TypeOf(utils.Meta{}))
+
+	paths := map[string]map[string]interface{}{}
+	err := router.Walk(func(route *mux.Route, owner *mux.Router, ancestors []*mux.Route) error {
+		name := handlerName(route.GetHandler())
+		if name == "" {
+			return nil
+		}
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		// OpenAPI path parameters carry no pattern
+		path := pathParamPattern.ReplaceAllString(template, "{$1}")
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+		for _, method := range methods {
+			paths[path][strings.ToLower(method)] = b.operation(name, path, routerAccess[owner])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Sentul Golf API",
+			"version": "1.0.0",
+			"description": "Responses are wrapped as {\"status\":\"success\",\"data\":...} or {\"status\":\"error\",\"error\":...}. " +
+				"List endpoints return a bare array with X-Total-Count and Link headers when X-Response-Style: bare is sent.",
+		},
+		"servers": []interface{}{map[string]interface{}{"url": config.GetEnv("BASE_URL", "/")}},
+		"paths":   paths, // encoding/json sorts the keys
+		"components": map[string]interface{}{
+			"schemas": b.components,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}, "", "  ")
+}
+
+// openAPIHandler serves the OpenAPI document of router, built on first use
+// once every route is registered
+func openAPIHandler(router *mux.Router) http.HandlerFunc {
+	var once sync.Once
+	var spec []byte
+	var specErr error
+	return func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			spec, specErr = buildOpenAPI(router)
+		})
+		if specErr != nil {
+			utils.RespondInternalError(w)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(spec)
+	}
+}
+
+// apiDocsPage renders Swagger UI for /api/openapi.json from the unpkg CDN
+const apiDocsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Sentul Golf API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/api/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// serveAPIDocs serves the Swagger UI page
+func serveAPIDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(apiDocsPage))
+}
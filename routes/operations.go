@@ -0,0 +1,142 @@
+package routes
+
+import (
+	"sentul-golf-be/handlers"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+)
+
+// operationDoc describes a handler for the OpenAPI document. Paths, methods
+// and authentication come from the router itself.
+type operationDoc struct {
+	summary  string
+	query    []string    // Query parameters
+	request  interface{} // JSON request body
+	form     []string    // multipart/form-data fields; "image" is the file
+	response interface{} // data of the success response
+	list     interface{} // Item of a paginated list response, instead of response
+	listKey  string      // Key the list is wrapped under in data ("" for data itself)
+	produces string      // Non-JSON content type of the success response
+	created  bool        // Success is 201 Created
+}
+
+// Common parameter sets
+var (
+	pageQuery        = []string{"page", "limit"}
+	contentListQuery = []string{"page", "limit", "search", "tag", "tag_mode", "featured", "deleted"}
+	newsForm         = []string{"title", "content", "slug", "excerpt", "published", "featured", "publish_at", "canonical_url", "tags", "image"}
+	eventForm        = []string{"title", "content", "slug", "excerpt", "published", "featured", "publish_at", "canonical_url", "tags", "event_start", "event_end", "location", "latitude", "longitude", "image"}
+	holeForm         = []string{"name", "description", "par", "distance", "stroke_index", "image"}
+	amenityForm      = []string{"name", "description", "icon", "sort_order", "active", "image"}
+	imageForm        = []string{"image"}
+)
+
+// message is the {"message": ...} data of simple confirmations
+type message struct {
+	Message string `json:"message"`
+}
+
+// currentUser is the data of GET /users/me
+type currentUser struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Email          string `json:"email"`
+	Role           string `json:"role"`
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
+}
+
+// operationDocs documents each handler by name. Handlers missing here are
+// still listed, with their name as the summary.
+var operationDocs = map[string]operationDoc{
+	// Auth
+	"Login":          {summary: "Log in and get a JWT", request: handlers.LoginRequest{}, response: handlers.LoginData{}},
+	"RefreshToken":   {summary: "Exchange a recently expired token for a new one", response: handlers.LoginData{}},
+	"Logout":         {summary: "Revoke the current token", response: message{}},
+	"ForgotPassword": {summary: "Email a password reset link", request: handlers.ForgotPasswordRequest{}, response: message{}},
+	"ResetPassword":  {summary: "Set a new password with a reset token", request: handlers.ResetPasswordRequest{}, response: message{}},
+
+	// Public content
+	"GetPosts":          {summary: "List published news and events", query: []string{"type", "sort", "page", "limit", "search", "q", "tag", "tag_mode", "status", "featured"}, list: handlers.PostResponse{}},
+	"GetHome":           {summary: "Homepage: latest, upcoming and featured (pinned) posts", response: handlers.HomeResponse{}},
+	"GetNewsByID":       {summary: "Get a news article by ID", response: handlers.NewsDetailResponse{}},
+	"GetNewsBySlug":     {summary: "Get a news article by slug", response: handlers.NewsDetailResponse{}},
+	"GetEventYears":     {summary: "Years that have published events", response: []handlers.EventYear{}},
+	"GetEventCalendar":  {summary: "iCalendar feed of published events", produces: "text/calendar"},
+	"GetEventByID":      {summary: "Get an event by ID", response: handlers.EventDetailResponse{}},
+	"GetEventBySlug":    {summary: "Get an event by slug", response: handlers.EventDetailResponse{}},
+	"GetHoles":          {summary: "List holes with the course summary", query: []string{"deleted"}, response: handlers.HolesListResponse{}},
+	"GetHole":           {summary: "Get a hole", response: models.Hole{}},
+	"GetHoleStats":      {summary: "Compare a hole with the rest of the course", response: handlers.HoleStats{}},
+	"GetAmenities":      {summary: "List active amenities", list: models.Amenity{}, listKey: "amenities"},
+	"GetNavigation":     {summary: "Sections that have content, with counts", response: handlers.NavigationResponse{}},
+	"GetScorecard":      {summary: "Course scorecard", response: handlers.ScorecardResponse{}},
+	"ReportClientError": {summary: "Report a frontend error", request: handlers.ClientErrorReport{}, response: map[string]string{}, created: true},
+	"GetSitemap":        {summary: "Sitemap of the public website", produces: "application/xml"},
+
+	// Current user
+	"GetCurrentUser": {summary: "Get the logged-in user", response: currentUser{}},
+	"ChangePassword": {summary: "Change the logged-in user's password", request: handlers.ChangePasswordRequest{}, response: message{}},
+	"GetMyNewsTrash": {summary: "List the caller's deleted news", query: pageQuery, list: handlers.TrashedItem{}, listKey: "news"},
+	"RestoreMyNews":  {summary: "Restore one of the caller's deleted news as a draft", response: map[string]interface{}{}},
+
+	// Users
+	"Register":          {summary: "Create a user", request: handlers.RegisterRequest{}, response: currentUser{}, created: true},
+	"GetUsers":          {summary: "List users", query: []string{"page", "limit", "search", "role"}, list: models.User{}, listKey: "users"},
+	"GetUser":           {summary: "Get a user", response: models.User{}},
+	"UpdateUser":        {summary: "Update a user (admins: any user, others: themselves)", request: handlers.UpdateUserRequest{}, response: models.User{}},
+	"DeleteUser":        {summary: "Delete a user", response: message{}},
+	"ImpersonateUser":   {summary: "Get a short-lived token acting as a user", response: map[string]interface{}{}},
+	"GetUserSessions":   {summary: "List a user's active sessions", response: map[string][]utils.Session{}},
+	"RevokeUserSession": {summary: "Revoke one of a user's sessions", response: message{}},
+
+	// News management
+	"GetNews":         {summary: "List news, drafts included", query: contentListQuery, list: handlers.NewsResponse{}, listKey: "news"},
+	"CreateNews":      {summary: "Create a news article", form: newsForm, response: map[string]interface{}{}, created: true},
+	"UpdateNews":      {summary: "Update a news article", form: append(newsForm, "delete_image"), response: map[string]interface{}{}},
+	"DeleteNews":      {summary: "Move a news article to the trash", response: message{}},
+	"RestoreNews":     {summary: "Restore a deleted news article as a draft", response: map[string]interface{}{}},
+	"PurgeNews":       {summary: "Permanently delete a news article", query: []string{"force"}, response: message{}},
+	"ExportNews":      {summary: "Export a news article as Markdown", query: []string{"format"}, produces: "text/markdown"},
+	"GetSimilarNews":  {summary: "Articles similar to a news article", response: []handlers.SimilarNews{}},
+	"AddNewsImage":    {summary: "Add a gallery image to a news article", form: imageForm, response: models.Image{}, created: true},
+	"DeleteNewsImage": {summary: "Remove a gallery image from a news article", response: message{}},
+
+	// Event management
+	"GetEvents":        {summary: "List events, drafts included", query: append(contentListQuery, "status", "start_after", "start_before"), list: handlers.EventResponse{}, listKey: "events"},
+	"CreateEvent":      {summary: "Create an event", form: eventForm, response: map[string]interface{}{}, created: true},
+	"UpdateEvent":      {summary: "Update an event", form: append(eventForm, "delete_image"), response: map[string]interface{}{}},
+	"DeleteEvent":      {summary: "Move an event to the trash", response: message{}},
+	"RestoreEvent":     {summary: "Restore a deleted event as a draft", response: map[string]interface{}{}},
+	"PurgeEvent":       {summary: "Permanently delete an event", query: []string{"force"}, response: message{}},
+	"AddEventImage":    {summary: "Add a gallery image to an event", form: imageForm, response: models.Image{}, created: true},
+	"DeleteEventImage": {summary: "Remove a gallery image from an event", response: message{}},
+
+	// Hole management
+	"CreateHole":           {summary: "Create a hole", form: holeForm, response: models.Hole{}, created: true},
+	"UpdateHole":           {summary: "Update a hole", form: append(holeForm, "delete_image"), response: map[string]interface{}{}},
+	"DeleteHole":           {summary: "Move a hole to the trash", response: message{}},
+	"RestoreHole":          {summary: "Restore a deleted hole at the end of the course", response: map[string]interface{}{}},
+	"PurgeHole":            {summary: "Permanently delete a hole", query: []string{"force"}, response: message{}},
+	"ReorderHoles":         {summary: "Set the order of all holes", request: handlers.ReorderHolesRequest{}, response: map[string]interface{}{}},
+	"CheckHoleIndices":     {summary: "Report gaps and duplicates in hole indices", response: handlers.HoleIndexReport{}},
+	"NormalizeHoleIndices": {summary: "Renumber holes 1..n in their current order", response: map[string]interface{}{}},
+
+	// Amenity management
+	"GetAllAmenities": {summary: "List all amenities, inactive included", list: models.Amenity{}, listKey: "amenities"},
+	"CreateAmenity":   {summary: "Create an amenity", form: amenityForm, response: models.Amenity{}, created: true},
+	"UpdateAmenity":   {summary: "Update an amenity", form: append(amenityForm, "delete_image"), response: map[string]interface{}{}},
+	"DeleteAmenity":   {summary: "Delete an amenity", response: map[string]interface{}{}},
+
+	// Editor and admin tools
+	"UploadContentImage":       {summary: "Upload an inline image for rich text content", form: imageForm, response: map[string]string{}},
+	"DeleteSingleContentImage": {summary: "Delete an inline content image", query: []string{"url"}, response: message{}},
+	"ValidateImage":            {summary: "Check an image without saving it", form: imageForm, response: utils.ImageInfo{}},
+	"SanitizePreview":          {summary: "Preview how content HTML will be sanitized", request: handlers.SanitizePreviewRequest{}, response: handlers.SanitizePreviewResponse{}},
+	"SweepOrphanUploads":       {summary: "Find, and with dry_run=false delete, unreferenced uploads", query: []string{"dry_run"}, response: utils.OrphanSweepReport{}},
+	"BulkTagContent":           {summary: "Add and remove tags on many items", request: handlers.BulkTagRequest{}, response: map[string]interface{}{}},
+	"CheckSlugs":               {summary: "Check whether slugs are available", request: handlers.SlugCheckRequest{}, response: map[string][]handlers.SlugCheckResult{}},
+	"GetDashboardStats":        {summary: "Counts and recent posts for the admin dashboard", response: handlers.DashboardStats{}},
+	"GetTimeseriesStats":       {summary: "Content counts over time", query: []string{"metric", "entity", "bucket", "from", "to"}, response: handlers.TimeseriesResponse{}},
+	"GetScheduledContent":      {summary: "Content scheduled for publishing", query: []string{"from", "to"}, response: handlers.ScheduledResponse{}},
+	"AdminSearch":              {summary: "Search news, events, holes and users", query: []string{"q", "limit"}, response: handlers.SearchResponse{}},
+}
@@ -19,44 +19,90 @@ func SetupRoutes() *mux.Router {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	// Static file serving for uploads
+	// Static file serving for uploads (supports Range requests)
 	router.PathPrefix("/uploads/").Handler(
-		http.StripPrefix("/uploads/", http.FileServer(http.Dir("./uploads"))),
-	)
+		http.StripPrefix("/uploads/", http.HandlerFunc(handlers.ServeUploads)),
+	).Methods("GET", "HEAD")
+
+	// Sitemap of the public website's pages for search engines
+	router.HandleFunc("/sitemap.xml", handlers.GetSitemap).Methods("GET")
 
 	// Public routes
 	api := router.PathPrefix("/api").Subrouter()
 	
-	// Auth routes - only login is public
+	// Auth routes - only login, token refresh and password reset are public
 	api.HandleFunc("/auth/login", handlers.Login).Methods("POST")
+	api.HandleFunc("/auth/refresh", handlers.RefreshToken).Methods("POST")
+	api.HandleFunc("/auth/forgot-password", handlers.ForgotPassword).Methods("POST")
+	api.HandleFunc("/auth/reset-password", handlers.ResetPassword).Methods("POST")
+
+	// Public read routes - a valid token is optional and only used to
+	// recognise admins (e.g. for ?no_cache=true)
+	public := api.PathPrefix("").Subrouter()
+	public.Use(middleware.OptionalAuth)
 
 	// Public posts endpoint - can filter by type (news or event)
-	api.HandleFunc("/posts", handlers.GetPosts).Methods("GET")
+	public.HandleFunc("/posts", handlers.GetPosts).Methods("GET")
+
+	// Public homepage: latest news and events, upcoming events and featured posts
+	public.HandleFunc("/home", handlers.GetHome).Methods("GET")
 	
 	// Public single post by slug or ID
-	api.HandleFunc("/news/{id:[0-9a-z]+}", handlers.GetNewsByID).Methods("GET")
-	api.HandleFunc("/news/slug/{slug}", handlers.GetNewsBySlug).Methods("GET")
-	api.HandleFunc("/events/{id:[0-9a-z]+}", handlers.GetEventByID).Methods("GET")
-	api.HandleFunc("/events/slug/{slug}", handlers.GetEventBySlug).Methods("GET")
+	// Slugs live under /slug/ and are never ID-shaped (see utils.IsIDLike),
+	// so an ID route can't accidentally match a slug
+	public.HandleFunc("/news/{id:[0-9a-z]+}", handlers.GetNewsByID).Methods("GET")
+	public.HandleFunc("/news/slug/{slug}", handlers.GetNewsBySlug).Methods("GET")
+	// Years with published events; registered before the ID route it would match
+	public.HandleFunc("/events/years", handlers.GetEventYears).Methods("GET")
+	// iCalendar feed of published events for calendar subscriptions
+	public.HandleFunc("/events/calendar.ics", handlers.GetEventCalendar).Methods("GET")
+	public.HandleFunc("/events/{id:[0-9a-z]+}", handlers.GetEventByID).Methods("GET")
+	public.HandleFunc("/events/slug/{slug}", handlers.GetEventBySlug).Methods("GET")
 
 	// Public holes
-	api.HandleFunc("/holes", handlers.GetHoles).Methods("GET")
-	api.HandleFunc("/holes/{id}", handlers.GetHole).Methods("GET")
+	public.HandleFunc("/holes", handlers.GetHoles).Methods("GET")
+	public.HandleFunc("/holes/{id}", handlers.GetHole).Methods("GET")
+	public.HandleFunc("/holes/{id}/stats", handlers.GetHoleStats).Methods("GET")
+
+	// Public club amenities (active only, in display order)
+	public.HandleFunc("/amenities", handlers.GetAmenities).Methods("GET")
+
+	// Public navigation: which sections have content, with counts
+	public.HandleFunc("/navigation", handlers.GetNavigation).Methods("GET")
+
+	// Public course scorecard
+	public.HandleFunc("/course/scorecard", handlers.GetScorecard).Methods("GET")
+
+	// Frontend error reports (authentication optional)
+	public.HandleFunc("/client-errors", handlers.ReportClientError).Methods("POST")
 
 	// Protected routes - require authentication
 	protected := api.PathPrefix("").Subrouter()
-	protected.Use(middleware.AuthMiddleware)
+	requireAuth(protected)
+	// Throttle create/update/delete requests per user
+	protected.Use(middleware.RateLimitWrites)
+	// Cap parallel multipart uploads per client IP
+	protected.Use(middleware.LimitConcurrentUploads)
+
+	// Logout revokes the current token
+	protected.HandleFunc("/auth/logout", handlers.Logout).Methods("POST")
 
 	// Get current user info (authenticated users)
 	protected.HandleFunc("/users/me", handlers.GetCurrentUser).Methods("GET")
+	protected.HandleFunc("/users/me/password", handlers.ChangePassword).Methods("PUT")
+	// Users may update their own record; UpdateUser lets admins update anyone
+	protected.HandleFunc("/users/{id}", handlers.UpdateUser).Methods("PUT")
+
+	// The caller's own deleted news (a personal recycle bin)
+	protected.HandleFunc("/news/trash/mine", handlers.GetMyNewsTrash).Methods("GET")
+	protected.HandleFunc("/news/trash/mine/{id}/restore", handlers.RestoreMyNews).Methods("POST")
 
 	// Admin-only routes - user management
 	adminUsers := protected.PathPrefix("/users").Subrouter()
-	adminUsers.Use(middleware.RequireAdmin)
+	requireAdmin(adminUsers)
 	adminUsers.HandleFunc("", handlers.Register).Methods("POST")
 	adminUsers.HandleFunc("", handlers.GetUsers).Methods("GET")
 	adminUsers.HandleFunc("/{id}", handlers.GetUser).Methods("GET")
-	adminUsers.HandleFunc("/{id}", handlers.UpdateUser).Methods("PUT")
 	adminUsers.HandleFunc("/{id}", handlers.DeleteUser).Methods("DELETE")
 
 	// Admin-only routes - content image upload (for rich text editor)
@@ -64,30 +110,92 @@ func SetupRoutes() *mux.Router {
 	// Delete a single content image in real-time (when user removes it from editor)
 	protected.HandleFunc("/admin/content-image", handlers.DeleteSingleContentImage).Methods("DELETE")
 
-	// Admin-only routes - news management (including GET all news)
+	// Admin-only routes - news management (including GET all news, and
+	// deleted news with ?deleted=true)
 	adminNews := protected.PathPrefix("/news").Subrouter()
-	adminNews.Use(middleware.RequireAdmin)
+	requireAdmin(adminNews)
 	adminNews.HandleFunc("", handlers.GetNews).Methods("GET")
 	adminNews.HandleFunc("", handlers.CreateNews).Methods("POST")
 	adminNews.HandleFunc("/{id}", handlers.UpdateNews).Methods("PUT")
 	adminNews.HandleFunc("/{id}", handlers.DeleteNews).Methods("DELETE")
-
-	// Admin-only routes - events management (including GET all events)
+	adminNews.HandleFunc("/{id}/restore", handlers.RestoreNews).Methods("POST")
+	adminNews.HandleFunc("/{id}/purge", handlers.PurgeNews).Methods("DELETE")
+	adminNews.HandleFunc("/{id}/export", handlers.ExportNews).Methods("GET")
+	adminNews.HandleFunc("/{id}/similar", handlers.GetSimilarNews).Methods("GET")
+	adminNews.HandleFunc("/{id}/images", handlers.AddNewsImage).Methods("POST")
+	adminNews.HandleFunc("/{id}/images/{imageId}", handlers.DeleteNewsImage).Methods("DELETE")
+
+	// Admin-only routes - events management (including GET all events, and
+	// deleted events with ?deleted=true)
 	adminEvents := protected.PathPrefix("/events").Subrouter()
-	adminEvents.Use(middleware.RequireAdmin)
+	requireAdmin(adminEvents)
 	adminEvents.HandleFunc("", handlers.GetEvents).Methods("GET")
 	adminEvents.HandleFunc("", handlers.CreateEvent).Methods("POST")
 	adminEvents.HandleFunc("/{id}", handlers.UpdateEvent).Methods("PUT")
 	adminEvents.HandleFunc("/{id}", handlers.DeleteEvent).Methods("DELETE")
+	adminEvents.HandleFunc("/{id}/restore", handlers.RestoreEvent).Methods("POST")
+	adminEvents.HandleFunc("/{id}/purge", handlers.PurgeEvent).Methods("DELETE")
+	adminEvents.HandleFunc("/{id}/images", handlers.AddEventImage).Methods("POST")
+	adminEvents.HandleFunc("/{id}/images/{imageId}", handlers.DeleteEventImage).Methods("DELETE")
 
 	// Admin-only routes - holes management
 	adminHoles := protected.PathPrefix("/admin/holes").Subrouter()
-	adminHoles.Use(middleware.RequireAdmin)
+	requireAdmin(adminHoles)
 	adminHoles.HandleFunc("", handlers.CreateHole).Methods("POST")
 	adminHoles.HandleFunc("/reorder", handlers.ReorderHoles).Methods("PUT")
+	adminHoles.HandleFunc("/index-check", handlers.CheckHoleIndices).Methods("GET")
+	adminHoles.HandleFunc("/normalize-indices", handlers.NormalizeHoleIndices).Methods("POST")
 	adminHoles.HandleFunc("/{id}", handlers.UpdateHole).Methods("PUT")
 	adminHoles.HandleFunc("/{id}", handlers.DeleteHole).Methods("DELETE")
+	adminHoles.HandleFunc("/{id}/restore", handlers.RestoreHole).Methods("POST")
+	adminHoles.HandleFunc("/{id}/purge", handlers.PurgeHole).Methods("DELETE")
+
+	// Admin-only routes - amenities management (including inactive ones)
+	adminAmenities := protected.PathPrefix("/admin/amenities").Subrouter()
+	requireAdmin(adminAmenities)
+	adminAmenities.HandleFunc("", handlers.GetAllAmenities).Methods("GET")
+	adminAmenities.HandleFunc("", handlers.CreateAmenity).Methods("POST")
+	adminAmenities.HandleFunc("/{id}", handlers.UpdateAmenity).Methods("PUT")
+	adminAmenities.HandleFunc("/{id}", handlers.DeleteAmenity).Methods("DELETE")
+
+	// Admin-only routes - editor tools
+	admin := protected.PathPrefix("/admin").Subrouter()
+	requireAdmin(admin)
+	admin.HandleFunc("/sanitize-preview", handlers.SanitizePreview).Methods("POST")
+	admin.HandleFunc("/validate-image", handlers.ValidateImage).Methods("POST")
+	admin.HandleFunc("/uploads/sweep", handlers.SweepOrphanUploads).Methods("POST")
+	admin.HandleFunc("/content/tag", handlers.BulkTagContent).Methods("POST")
+	admin.HandleFunc("/slugs/check", handlers.CheckSlugs).Methods("POST")
+	admin.HandleFunc("/stats", handlers.GetDashboardStats).Methods("GET")
+	admin.HandleFunc("/stats/timeseries", handlers.GetTimeseriesStats).Methods("GET")
+	admin.HandleFunc("/scheduled", handlers.GetScheduledContent).Methods("GET")
+	admin.HandleFunc("/search", handlers.AdminSearch).Methods("GET")
+	admin.HandleFunc("/users/{id}/impersonate", handlers.ImpersonateUser).Methods("POST")
+	admin.HandleFunc("/users/{id}/sessions", handlers.GetUserSessions).Methods("GET")
+	admin.HandleFunc("/users/{id}/sessions/{sessionId}", handlers.RevokeUserSession).Methods("DELETE")
+
+	// OpenAPI description of the routes above, and Swagger UI to browse it
+	api.HandleFunc("/openapi.json", openAPIHandler(router)).Methods("GET")
+	api.HandleFunc("/docs", serveAPIDocs).Methods("GET")
+
+	// Preflight responses advertise only the methods of the requested route
+	middleware.SetCORSRouteMethods(func(r *http.Request) []string {
+		return routeMethods(router, r)
+	})
 
 	return router
 }
 
+// routeMethods returns the methods router has a route for at the request's path
+func routeMethods(router *mux.Router, r *http.Request) []string {
+	var methods []string
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req := r.Clone(r.Context())
+		req.Method = method
+		if router.Match(req, &mux.RouteMatch{}) {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
@@ -0,0 +1,182 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+	"sentul-golf-be/utils"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testPassword is the password of every user setupRouterTest creates
+const testPassword = "golf2025"
+
+// setupRouterTest loads a JWT secret and points config.DB at an in-memory
+// database with every model migrated
+func setupRouterTest(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	t.Setenv("JWT_SECRET", strings.Repeat("s", config.MinJWTSecretLength))
+	if err := utils.LoadJWTConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	dsn := "file:" + strings.NewReplacer("/", "_", " ", "_").Replace(t.Name()) + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.News{},
+		&models.Event{},
+		&models.Hole{},
+		&models.Amenity{},
+		&models.Tag{},
+		&models.Image{},
+		&models.SlugHistory{},
+		&models.AuditLog{},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := config.DB
+	config.DB = db
+	t.Cleanup(func() {
+		config.DB = previous
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// createRouterTestUser inserts a user with testPassword and returns it with a token
+func createRouterTestUser(t *testing.T, db *gorm.DB, email string, role models.Role) (models.User, string) {
+	t.Helper()
+
+	hashed, err := utils.HashPassword(testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := models.User{Name: "Test " + string(role), Email: email, Password: hashed, Role: role}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	token, _, err := utils.GenerateJWT(user.ID, user.Email, string(role))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user, token
+}
+
+func TestUpdateUserRoute(t *testing.T) {
+	tests := []struct {
+		name       string
+		caller     string // "user", "admin" or "" for no token
+		target     string // "user" or "other"
+		body       map[string]string
+		wantStatus int
+		wantName   string
+		wantEmail  string
+		wantRole   models.Role
+	}{
+		{
+			name: "own name", caller: "user", target: "user",
+			body:       map[string]string{"name": "New Name"},
+			wantStatus: http.StatusOK, wantName: "New Name", wantEmail: "user@example.com", wantRole: models.RoleUser,
+		},
+		{
+			name: "own role is ignored", caller: "user", target: "user",
+			body:       map[string]string{"role": "admin"},
+			wantStatus: http.StatusOK, wantName: "Test user", wantEmail: "user@example.com", wantRole: models.RoleUser,
+		},
+		{
+			name: "own email without current password", caller: "user", target: "user",
+			body:       map[string]string{"email": "new@example.com"},
+			wantStatus: http.StatusUnprocessableEntity, wantName: "Test user", wantEmail: "user@example.com", wantRole: models.RoleUser,
+		},
+		{
+			name: "own email with wrong current password", caller: "user", target: "user",
+			body:       map[string]string{"email": "new@example.com", "current_password": "wrong-pass1"},
+			wantStatus: http.StatusUnprocessableEntity, wantName: "Test user", wantEmail: "user@example.com", wantRole: models.RoleUser,
+		},
+		{
+			name: "own email with current password", caller: "user", target: "user",
+			body:       map[string]string{"email": "new@example.com", "current_password": testPassword},
+			wantStatus: http.StatusOK, wantName: "Test user", wantEmail: "new@example.com", wantRole: models.RoleUser,
+		},
+		{
+			name: "own password without current password", caller: "user", target: "user",
+			body:       map[string]string{"password": "newgolf2025"},
+			wantStatus: http.StatusUnprocessableEntity, wantName: "Test user", wantEmail: "user@example.com", wantRole: models.RoleUser,
+		},
+		{
+			name: "another user", caller: "user", target: "other",
+			body:       map[string]string{"name": "Hijacked"},
+			wantStatus: http.StatusForbidden, wantName: "Test user", wantEmail: "other@example.com", wantRole: models.RoleUser,
+		},
+		{
+			name: "admin updates another user", caller: "admin", target: "other",
+			body:       map[string]string{"email": "renamed@example.com", "role": "admin"},
+			wantStatus: http.StatusOK, wantName: "Test user", wantEmail: "renamed@example.com", wantRole: models.RoleAdmin,
+		},
+		{
+			name: "no token", target: "user",
+			body:       map[string]string{"name": "New Name"},
+			wantStatus: http.StatusUnauthorized, wantName: "Test user", wantEmail: "user@example.com", wantRole: models.RoleUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupRouterTest(t)
+			user, userToken := createRouterTestUser(t, db, "user@example.com", models.RoleUser)
+			other, _ := createRouterTestUser(t, db, "other@example.com", models.RoleUser)
+			_, adminToken := createRouterTestUser(t, db, "admin@example.com", models.RoleAdmin)
+			target := user
+			if tt.target == "other" {
+				target = other
+			}
+
+			body, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest(http.MethodPut, "/api/users/"+target.ID, bytes.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			switch tt.caller {
+			case "user":
+				r.Header.Set("Authorization", "Bearer "+userToken)
+			case "admin":
+				r.Header.Set("Authorization", "Bearer "+adminToken)
+			}
+			w := httptest.NewRecorder()
+			SetupRoutes().ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			var stored models.User
+			if err := db.First(&stored, "id = ?", target.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if stored.Name != tt.wantName || stored.Email != tt.wantEmail || stored.Role != tt.wantRole {
+				t.Errorf("stored user = %q <%s> (%s), want %q <%s> (%s)",
+					stored.Name, stored.Email, stored.Role, tt.wantName, tt.wantEmail, tt.wantRole)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image/gif"
+	"io"
+	"strings"
+
+	"sentul-golf-be/config"
+)
+
+// RequiresStaticImage reports whether uploads to subfolder must be single-frame
+// images. Configured with STATIC_IMAGE_FOLDERS (default: news,events,holes).
+func RequiresStaticImage(subfolder string) bool {
+	for _, folder := range strings.Split(config.GetEnv("STATIC_IMAGE_FOLDERS", "news,events,holes"), ",") {
+		if strings.TrimSpace(folder) == subfolder {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAnimatedImage reports whether the file is a multi-frame image
+// (animated WebP, APNG or animated GIF). The file is rewound afterwards.
+func IsAnimatedImage(file io.ReadSeeker) (bool, error) {
+	buffer := make([]byte, 512)
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false, errors.New("failed to read file")
+	}
+	buffer = buffer[:n]
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return false, errors.New("failed to reset file pointer")
+	}
+
+	switch {
+	case len(buffer) >= 21 && string(buffer[0:4]) == "RIFF" && string(buffer[8:12]) == "WEBP":
+		// Extended WebP (VP8X) sets the animation flag (bit 1) in its header
+		return string(buffer[12:16]) == "VP8X" && buffer[20]&0x02 != 0, nil
+	case bytes.HasPrefix(buffer, []byte("\x89PNG\r\n\x1a\n")):
+		animated, err := isAPNG(file)
+		if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil {
+			return false, errors.New("failed to reset file pointer")
+		}
+		return animated, err
+	case bytes.HasPrefix(buffer, []byte("GIF8")):
+		images, err := gif.DecodeAll(file)
+		if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil {
+			return false, errors.New("failed to reset file pointer")
+		}
+		if err != nil {
+			return false, errors.New("failed to decode GIF")
+		}
+		return len(images.Image) > 1, nil
+	}
+
+	return false, nil
+}
+
+// isAPNG walks the PNG chunks and reports whether an animation control
+// chunk (acTL) appears before the image data
+func isAPNG(file io.ReadSeeker) (bool, error) {
+	if _, err := file.Seek(8, io.SeekStart); err != nil {
+		return false, errors.New("failed to read file")
+	}
+
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(file, header); err != nil {
+			return false, nil
+		}
+		length := binary.BigEndian.Uint32(header[0:4])
+		switch string(header[4:8]) {
+		case "acTL":
+			return true, nil
+		case "IDAT", "IEND":
+			return false, nil
+		}
+		// Skip the chunk data and CRC
+		if _, err := file.Seek(int64(length)+4, io.SeekCurrent); err != nil {
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIsAnimatedImage(t *testing.T) {
+	tests := []struct {
+		name string
+		data func(t *testing.T) []byte
+		want bool
+	}{
+		{"animated webp", func(*testing.T) []byte { return testWebP(4, 4, true) }, true},
+		{"static webp", func(*testing.T) []byte { return testWebP(4, 4, false) }, false},
+		{"apng", testAPNG, true},
+		{"static png", func(t *testing.T) []byte { return testPNG(t, 4, 4) }, false},
+		{"animated gif", func(t *testing.T) []byte { return testGIF(t, 2) }, true},
+		{"single frame gif", func(t *testing.T) []byte { return testGIF(t, 1) }, false},
+		{"empty", func(*testing.T) []byte { return nil }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := bytes.NewReader(tt.data(t))
+			got, err := IsAnimatedImage(file)
+			if err != nil {
+				t.Fatalf("IsAnimatedImage() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsAnimatedImage() = %v, want %v", got, tt.want)
+			}
+			if offset, _ := file.Seek(0, 1); offset != 0 {
+				t.Errorf("file offset = %d after IsAnimatedImage, want 0", offset)
+			}
+		})
+	}
+}
+
+func TestSaveImageRejectsAnimatedImages(t *testing.T) {
+	tests := []struct {
+		name      string
+		folders   string // STATIC_IMAGE_FOLDERS, "" for the default
+		subfolder string
+		filename  string
+		data      []byte
+		wantErr   bool
+	}{
+		{"animated webp in news", "", "news", "banner.webp", testWebP(4, 4, true), true},
+		{"animated webp in events", "", "events", "banner.webp", testWebP(4, 4, true), true},
+		{"animated webp in holes", "", "holes", "banner.webp", testWebP(4, 4, true), true},
+		{"static webp in news", "", "news", "banner.webp", testWebP(4, 4, false), false},
+		{"animated webp in content", "", "content", "banner.webp", testWebP(4, 4, true), false},
+		{"animated webp in a configured folder", "content", "content", "banner.webp", testWebP(4, 4, true), true},
+		{"animated webp outside the configured folders", "content", "news", "banner.webp", testWebP(4, 4, true), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestUploads(t)
+			if tt.folders != "" {
+				t.Setenv("STATIC_IMAGE_FOLDERS", tt.folders)
+			}
+
+			file, header := newTestUpload(tt.filename, tt.data)
+			result, err := SaveImage(file, header, tt.subfolder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SaveImage() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && result.Path == "" {
+				t.Errorf("SaveImage() stored no file")
+			}
+		})
+	}
+}
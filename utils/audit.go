@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"log"
+	"time"
+
+	"sentul-golf-be/config"
+	"sentul-golf-be/models"
+)
+
+// auditBufferSize is the number of audit entries held in memory before
+// RecordAudit falls back to writing synchronously
+const auditBufferSize = 1000
+
+// auditFlushBatchSize is the number of audit entries inserted per statement
+const auditFlushBatchSize = 100
+
+// auditBuffer queues audit entries until the next flush
+var auditBuffer = make(chan models.AuditLog, auditBufferSize)
+
+// RecordAudit logs an audit entry and queues it for the database. When the
+// buffer is full the entry is written right away so it is never dropped.
+func RecordAudit(entry models.AuditLog) {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	log.Printf("AUDIT: %s by %s on %s %s %s", entry.Action, entry.ActorID, entry.TargetType, entry.TargetID, entry.Details)
+
+	select {
+	case auditBuffer <- entry:
+	default:
+		if err := config.GetDB().Create(&entry).Error; err != nil {
+			log.Printf("Warning: Failed to write audit entry: %v", err)
+		}
+	}
+}
+
+// auditFlushAttempts is how often a batch insert is tried before its entries
+// go back on the buffer for the next flush
+const auditFlushAttempts = 3
+
+// auditRetryBackoff is the wait before the second attempt, doubled for each one after
+var auditRetryBackoff = 200 * time.Millisecond
+
+// FlushAuditLogs writes all buffered audit entries to the database in batches.
+// A failing batch is retried with backoff and then put back on the buffer, so
+// entries are only dropped when the buffer has no room left for them.
+func FlushAuditLogs() error {
+	for {
+		// Take what is buffered right now, up to one batch
+		var batch []models.AuditLog
+	drain:
+		for len(batch) < auditFlushBatchSize {
+			select {
+			case entry := <-auditBuffer:
+				batch = append(batch, entry)
+			default:
+				break drain
+			}
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := insertAuditBatch(batch); err != nil {
+			requeueAuditEntries(batch)
+			return err
+		}
+	}
+}
+
+// insertAuditBatch inserts batch, retrying with backoff when it fails
+func insertAuditBatch(batch []models.AuditLog) error {
+	backoff := auditRetryBackoff
+	var err error
+	for attempt := 1; attempt <= auditFlushAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = config.GetDB().Create(&batch).Error; err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// requeueAuditEntries puts entries back on the buffer for the next flush,
+// dropping the ones that don't fit
+func requeueAuditEntries(entries []models.AuditLog) {
+	for i, entry := range entries {
+		select {
+		case auditBuffer <- entry:
+		default:
+			// The entries are already in the application log
+			log.Printf("Warning: Dropped %d audit entries, the audit buffer is full", len(entries)-i)
+			return
+		}
+	}
+}
+
+// StartAuditFlusher writes buffered audit entries to the DB every interval in the background
+func StartAuditFlusher(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := FlushAuditLogs(); err != nil {
+				log.Printf("Warning: Failed to flush audit logs: %v", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"sentul-golf-be/models"
+)
+
+// drainAuditBuffer empties the audit buffer before and after a test
+func drainAuditBuffer(t *testing.T) {
+	t.Helper()
+
+	drain := func() {
+		for {
+			select {
+			case <-auditBuffer:
+			default:
+				return
+			}
+		}
+	}
+	drain()
+	t.Cleanup(drain)
+}
+
+func TestFlushAuditLogs(t *testing.T) {
+	previousBackoff := auditRetryBackoff
+	auditRetryBackoff = time.Millisecond
+	t.Cleanup(func() { auditRetryBackoff = previousBackoff })
+
+	tests := []struct {
+		name         string
+		entries      int
+		failing      bool // The audit table is missing during the flush
+		wantWritten  int64
+		wantBuffered int
+	}{
+		{"written in batches", auditFlushBatchSize + 5, false, auditFlushBatchSize + 5, 0},
+		{"failed batch is requeued", 5, true, 0, 5},
+		{"full buffer is requeued", auditBufferSize, true, 0, auditBufferSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t, &models.AuditLog{})
+			drainAuditBuffer(t)
+
+			for i := 0; i < tt.entries; i++ {
+				RecordAudit(models.AuditLog{Action: "test", ActorID: "user", TargetType: "news", TargetID: "1"})
+			}
+			if tt.failing {
+				if err := db.Migrator().DropTable(&models.AuditLog{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := FlushAuditLogs()
+			if (err != nil) != tt.failing {
+				t.Fatalf("FlushAuditLogs() error = %v, want error %v", err, tt.failing)
+			}
+			if got := len(auditBuffer); got != tt.wantBuffered {
+				t.Errorf("buffered entries = %d, want %d", got, tt.wantBuffered)
+			}
+			if tt.failing {
+				// The next flush writes the requeued entries
+				if err := db.AutoMigrate(&models.AuditLog{}); err != nil {
+					t.Fatal(err)
+				}
+				if err := FlushAuditLogs(); err != nil {
+					t.Fatalf("FlushAuditLogs() after recovery error = %v", err)
+				}
+				tt.wantWritten = int64(tt.wantBuffered)
+			}
+
+			var written int64
+			db.Model(&models.AuditLog{}).Count(&written)
+			if written != tt.wantWritten {
+				t.Errorf("written entries = %d, want %d", written, tt.wantWritten)
+			}
+		})
+	}
+}
+
+func TestRequeueAuditEntriesDropsOverflow(t *testing.T) {
+	drainAuditBuffer(t)
+
+	for i := 0; i < auditBufferSize-2; i++ {
+		auditBuffer <- models.AuditLog{Action: "queued"}
+	}
+	requeueAuditEntries(make([]models.AuditLog, 5))
+
+	if got := len(auditBuffer); got != auditBufferSize {
+		t.Errorf("buffered entries = %d, want %d", got, auditBufferSize)
+	}
+}
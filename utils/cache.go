@@ -17,6 +17,24 @@ const (
 	CacheTTLNewsDetail  = 1 * time.Hour
 	CacheTTLEventsList  = 15 * time.Minute
 	CacheTTLEventDetail = 1 * time.Hour
+	CacheTTLScorecard   = 1 * time.Hour
+	CacheTTLPostsList   = 15 * time.Minute
+	CacheTTLSimilarNews = 15 * time.Minute
+	CacheTTLHome        = 5 * time.Minute
+	CacheTTLAmenities   = 1 * time.Hour
+	CacheTTLNavigation  = 1 * time.Minute
+
+	CacheTTLDashboardStats = 1 * time.Minute
+)
+
+// Cache keys of views built from news and events alongside their own lists.
+// Variants append to the key, e.g. home:limit:5 or nav:hide_past.
+const (
+	CacheKeyHome           = "home"
+	CacheKeyNavigation     = "nav"
+	CacheKeyDashboardStats = "stats:dashboard"
+	CacheKeyEventCalendar  = "event:calendar"
+	CacheKeyEventYears     = "event:years"
 )
 
 // IsRedisAvailable checks if Redis client is connected
@@ -99,6 +117,36 @@ func CacheDeletePattern(ctx context.Context, pattern string) error {
 	return nil
 }
 
+// InvalidateContentCaches clears every cache derived from news or events
+// after entity ("news" or "event") changed: its lists, the posts feed, the
+// home page, navigation and admin dashboard, the event calendar and years,
+// related-article suggestions, and the detail views of the given ids and
+// slugs. Every content mutation goes through it so none is forgotten.
+func InvalidateContentCaches(ctx context.Context, entity string, ids, slugs []string) {
+	_ = CacheDeletePattern(ctx, entity+":list:*")
+	_ = CacheDeletePattern(ctx, "post:list:*")
+	_ = CacheDeletePattern(ctx, CacheKeyHome+":*")
+	_ = CacheDelete(ctx, CacheKeyNavigation)
+	_ = CacheDeletePattern(ctx, CacheKeyNavigation+":*")
+	_ = CacheDelete(ctx, CacheKeyDashboardStats)
+	if entity == "event" {
+		_ = CacheDelete(ctx, CacheKeyEventCalendar)
+		_ = CacheDelete(ctx, CacheKeyEventYears)
+	}
+	// Suggestions for one article list others, so any news change can affect them
+	if entity == "news" {
+		_ = CacheDeletePattern(ctx, "news:similar:*")
+	}
+	for _, id := range ids {
+		_ = CacheDelete(ctx, BuildCacheKey(entity, "id", id))
+	}
+	for _, slug := range slugs {
+		if slug != "" {
+			_ = CacheDelete(ctx, BuildCacheKey(entity, "slug", slug))
+		}
+	}
+}
+
 // BuildCacheKey builds a cache key from parts
 func BuildCacheKey(parts ...interface{}) string {
 	key := ""
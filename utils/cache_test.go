@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestInvalidateContentCaches(t *testing.T) {
+	cached := []string{
+		"news:list:page:1:limit:10:published:true",
+		"news:id:n1",
+		"news:id:n2",
+		"news:slug:opening-day",
+		"news:slug:other",
+		"news:similar:n2:limit:3",
+		"event:list:page:1:limit:10:published:true",
+		"event:years",
+		"event:calendar",
+		"event:id:e1",
+		"event:slug:club-night",
+		"post:list:page:1",
+		"home:limit:5",
+		"nav",
+		"nav:hide_past",
+		"stats:dashboard",
+		"tag:list",
+	}
+
+	tests := []struct {
+		name   string
+		entity string
+		ids    []string
+		slugs  []string
+		want   []string // Keys left afterwards
+	}{
+		{
+			name:   "news created",
+			entity: "news",
+			want: []string{
+				"event:calendar", "event:id:e1", "event:list:page:1:limit:10:published:true", "event:slug:club-night", "event:years",
+				"news:id:n1", "news:id:n2", "news:slug:opening-day", "news:slug:other", "tag:list",
+			},
+		},
+		{
+			name:   "news slug changed",
+			entity: "news",
+			ids:    []string{"n1"},
+			slugs:  []string{"old-slug", "opening-day", ""},
+			want: []string{
+				"event:calendar", "event:id:e1", "event:list:page:1:limit:10:published:true", "event:slug:club-night", "event:years",
+				"news:id:n2", "news:slug:other", "tag:list",
+			},
+		},
+		{
+			name:   "event deleted",
+			entity: "event",
+			ids:    []string{"e1"},
+			slugs:  []string{"club-night"},
+			want: []string{
+				"news:id:n1", "news:id:n2", "news:list:page:1:limit:10:published:true", "news:similar:n2:limit:3",
+				"news:slug:opening-day", "news:slug:other", "tag:list",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupTestRedis(t)
+			for _, key := range cached {
+				server.Set(key, "{}")
+			}
+
+			InvalidateContentCaches(context.Background(), tt.entity, tt.ids, tt.slugs)
+
+			got := server.Keys()
+			sort.Strings(got)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("keys left = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"errors"
+	"time"
+)
+
+// ParseDate parses a date in RFC3339 (2006-01-02T15:04:05Z07:00) or
+// date-only (YYYY-MM-DD, interpreted as 00:00:00 UTC) format
+func ParseDate(value string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return parsed, nil
+	}
+
+	// Try alternative format (date only, will use 00:00:00)
+	parsed, err = time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date format")
+	}
+	return parsed, nil
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// WeakETag returns a weak entity tag for a response body. Bodies are built
+// from the cached value, so cache hits and misses get the same tag.
+func WeakETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, using the
+// weak comparison If-None-Match calls for
+func etagMatches(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSONWithETag tags a JSON body with its ETag and sends it, or just
+// 304 Not Modified when the client already has it
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, value interface{}) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		RespondInternalError(w)
+		return
+	}
+	body = append(body, '\n')
+
+	etag := WeakETag(body)
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// RespondSuccessETag sends a 200 success response like RespondSuccess with a
+// weak ETag, answering a matching If-None-Match with 304 Not Modified
+func RespondSuccessETag(w http.ResponseWriter, r *http.Request, data interface{}, meta *Meta) {
+	writeJSONWithETag(w, r, SuccessResponse{
+		Status: "success",
+		Data:   data,
+		Meta:   meta,
+	})
+}
@@ -5,9 +5,29 @@ import (
 	"strings"
 )
 
+// MaxExcerptLength matches the size of the excerpt column
+const MaxExcerptLength = 200
+
 // MakeExcerpt generates a plain text excerpt from HTML content
 // It strips HTML tags and limits the text to the specified character limit
 func MakeExcerpt(html string, limit int) string {
+	clean := htmlToText(html)
+
+	// Truncate to limit if necessary
+	if len(clean) > limit {
+		return clean[:limit] + "..."
+	}
+
+	return clean
+}
+
+// CleanExcerpt turns a manually written excerpt into trimmed plain text
+func CleanExcerpt(text string) string {
+	return htmlToText(text)
+}
+
+// htmlToText strips HTML tags and collapses whitespace
+func htmlToText(html string) string {
 	if html == "" {
 		return ""
 	}
@@ -23,12 +43,5 @@ func MakeExcerpt(html string, limit int) string {
 	// Clean up multiple whitespaces and trim
 	multiSpace := regexp.MustCompile(`\s+`)
 	clean := multiSpace.ReplaceAllString(text, " ")
-	clean = strings.TrimSpace(clean)
-
-	// Truncate to limit if necessary
-	if len(clean) > limit {
-		return clean[:limit] + "..."
-	}
-
-	return clean
+	return strings.TrimSpace(clean)
 }
@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"mime/multipart"
+	"os"
+	"strings"
+	"testing"
+
+	"sentul-golf-be/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testFile is an in-memory multipart.File
+type testFile struct {
+	*bytes.Reader
+}
+
+func (testFile) Close() error { return nil }
+
+// newTestUpload returns data as an uploaded file with the given name
+func newTestUpload(name string, data []byte) (multipart.File, *multipart.FileHeader) {
+	return testFile{bytes.NewReader(data)}, &multipart.FileHeader{Filename: name, Size: int64(len(data))}
+}
+
+// setupTestUploads runs the test in a temporary working directory so local
+// storage writes below it
+func setupTestUploads(t *testing.T) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// testPNG returns a valid PNG image of the given size
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testAPNG returns a PNG with an animation control chunk after its header
+func testAPNG(t *testing.T) []byte {
+	t.Helper()
+
+	data := testPNG(t, 4, 4)
+	actl := make([]byte, 8) // Number of frames and plays
+	binary.BigEndian.PutUint32(actl[0:4], 2)
+
+	var chunk bytes.Buffer
+	binary.Write(&chunk, binary.BigEndian, uint32(len(actl)))
+	chunk.WriteString("acTL")
+	chunk.Write(actl)
+	binary.Write(&chunk, binary.BigEndian, crc32.ChecksumIEEE(append([]byte("acTL"), actl...)))
+
+	// The signature and IHDR chunk take the first 33 bytes
+	return append(append(append([]byte{}, data[:33]...), chunk.Bytes()...), data[33:]...)
+}
+
+// testGIF returns a GIF with the given number of frames
+func testGIF(t *testing.T, frames int) []byte {
+	t.Helper()
+
+	animation := &gif.GIF{}
+	for i := 0; i < frames; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, 4, 4), color.Palette{color.Black, color.White})
+		frame.SetColorIndex(i%4, 0, 1)
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, 10)
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, animation); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testWebP returns the header of an extended (VP8X) WebP image with the
+// given canvas size and, when animated, the animation flag set
+func testWebP(width, height int, animated bool) []byte {
+	data := make([]byte, 30)
+	copy(data[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(data)-8))
+	copy(data[8:12], "WEBP")
+	copy(data[12:16], "VP8X")
+	binary.LittleEndian.PutUint32(data[16:20], 10)
+	if animated {
+		data[20] = 0x02
+	}
+	w, h := width-1, height-1
+	data[24], data[25], data[26] = byte(w), byte(w>>8), byte(w>>16)
+	data[27], data[28], data[29] = byte(h), byte(h>>8), byte(h>>16)
+	return data
+}
+
+// setupTestRedis points config.RedisClient at an in-memory Redis server and
+// restores the previous client afterwards
+func setupTestRedis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	previous := config.RedisClient
+	config.RedisClient = redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		config.RedisClient.Close()
+		config.RedisClient = previous
+	})
+	return server
+}
+
+// setupTestDB points config.DB at a fresh in-memory SQLite database with the
+// given models migrated, and restores the previous database afterwards
+func setupTestDB(t *testing.T, tables ...interface{}) *gorm.DB {
+	t.Helper()
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", name)
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	if err := db.AutoMigrate(tables...); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+
+	previous := config.DB
+	config.DB = db
+	t.Cleanup(func() {
+		config.DB = previous
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
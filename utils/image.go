@@ -2,27 +2,37 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"mime/multipart"
 	"net/http"
-	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"sentul-golf-be/config"
+
 	"github.com/google/uuid"
 )
 
 const (
-	MaxImageSize = 5 * 1024 * 1024 // 5MB
-	UploadDir    = "./uploads"
+	DefaultMaxImageSize = 5 * 1024 * 1024 // 5MB
+	UploadDir           = "./uploads"
 )
 
+// MaxImageSize is the largest accepted upload in bytes, set from
+// MAX_IMAGE_SIZE_MB by LoadImageConfig
+var MaxImageSize int64 = DefaultMaxImageSize
+
 var (
-	// Allowed image MIME types
+	// Allowed image MIME types. ALLOWED_IMAGE_TYPES can only narrow these
+	// down, since other types can't be verified by their magic bytes.
 	allowedMimeTypes = map[string]bool{
 		"image/jpeg": true,
 		"image/jpg":  true,
@@ -31,13 +41,14 @@ var (
 		"image/webp": true,
 	}
 
-	// Allowed file extensions
-	allowedExtensions = map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".heic": true,
-		".webp": true,
+	// Allowed file extensions and the content types each one may contain.
+	// The detected content type must be listed for the file's extension.
+	extensionContentTypes = map[string][]string{
+		".jpg":  {"image/jpeg"},
+		".jpeg": {"image/jpeg"},
+		".png":  {"image/png"},
+		".heic": {"image/heic"},
+		".webp": {"image/webp"},
 	}
 
 	// Magic bytes for image validation
@@ -50,6 +61,90 @@ var (
 	}
 )
 
+// LoadImageConfig reads the upload limits from the environment:
+// MAX_IMAGE_SIZE_MB (default 5) and ALLOWED_IMAGE_TYPES, a comma-separated
+// list of MIME types out of image/jpeg, image/png, image/heic and image/webp.
+// An invalid size keeps the default; an unsupported type is an error.
+func LoadImageConfig() error {
+	if value := config.GetEnv("MAX_IMAGE_SIZE_MB", ""); value != "" {
+		mb, err := strconv.Atoi(value)
+		if err != nil || mb <= 0 {
+			log.Printf("Warning: Invalid MAX_IMAGE_SIZE_MB %q, using %dMB", value, MaxImageSize>>20)
+		} else {
+			MaxImageSize = int64(mb) << 20
+		}
+	}
+
+	value := config.GetEnv("ALLOWED_IMAGE_TYPES", "")
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	allowed := make(map[string]bool)
+	for _, contentType := range strings.Split(value, ",") {
+		contentType = strings.ToLower(strings.TrimSpace(contentType))
+		if contentType == "" {
+			continue
+		}
+		if contentType == "image/jpg" {
+			contentType = "image/jpeg"
+		}
+		if imageMagicBytes[contentType] == nil {
+			return fmt.Errorf("ALLOWED_IMAGE_TYPES: %s is not a supported image type", contentType)
+		}
+		allowed[contentType] = true
+	}
+	if len(allowed) == 0 {
+		return errors.New("ALLOWED_IMAGE_TYPES lists no image types")
+	}
+
+	// Drop the types that weren't listed, and extensions left without a type
+	for contentType := range allowedMimeTypes {
+		if contentType == "image/jpg" && allowed["image/jpeg"] {
+			continue
+		}
+		if !allowed[contentType] {
+			delete(allowedMimeTypes, contentType)
+		}
+	}
+	for ext, contentTypes := range extensionContentTypes {
+		var kept []string
+		for _, contentType := range contentTypes {
+			if allowed[contentType] {
+				kept = append(kept, contentType)
+			}
+		}
+		if len(kept) == 0 {
+			delete(extensionContentTypes, ext)
+		} else {
+			extensionContentTypes[ext] = kept
+		}
+	}
+	return nil
+}
+
+// allowedExtensions lists the accepted file extensions for error messages
+func allowedExtensions() string {
+	exts := make([]string, 0, len(extensionContentTypes))
+	for ext := range extensionContentTypes {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return strings.Join(exts, ", ")
+}
+
+// allowedContentTypes lists the accepted MIME types for error messages
+func allowedContentTypes() string {
+	contentTypes := make([]string, 0, len(allowedMimeTypes))
+	for contentType := range allowedMimeTypes {
+		if contentType != "image/jpg" {
+			contentTypes = append(contentTypes, contentType)
+		}
+	}
+	sort.Strings(contentTypes)
+	return strings.Join(contentTypes, ", ")
+}
+
 // ImageUploadResult contains the result of an image upload
 type ImageUploadResult struct {
 	Filename string
@@ -62,7 +157,7 @@ type ImageUploadResult struct {
 func ValidateImageFile(file multipart.File, header *multipart.FileHeader) error {
 	// Check file size
 	if header.Size > MaxImageSize {
-		return errors.New("file size exceeds maximum allowed size of 5MB")
+		return fmt.Errorf("file size exceeds maximum allowed size of %dMB", MaxImageSize>>20)
 	}
 
 	if header.Size == 0 {
@@ -71,8 +166,8 @@ func ValidateImageFile(file multipart.File, header *multipart.FileHeader) error
 
 	// Check file extension
 	ext := strings.ToLower(filepath.Ext(header.Filename))
-	if !allowedExtensions[ext] {
-		return fmt.Errorf("file extension %s is not allowed. Only .jpg, .jpeg, .png, .heic, and .webp are allowed", ext)
+	if extensionContentTypes[ext] == nil {
+		return fmt.Errorf("file extension %s is not allowed. Only %s are allowed", ext, allowedExtensions())
 	}
 
 	// Read the first 512 bytes to detect content type
@@ -87,20 +182,17 @@ func ValidateImageFile(file multipart.File, header *multipart.FileHeader) error
 		return errors.New("failed to reset file pointer")
 	}
 
-	// Detect content type using http.DetectContentType
-	contentType := http.DetectContentType(buffer)
+	// Detect content type from the file content
+	contentType := detectImageContentType(buffer)
 	
 	// Validate MIME type
 	if !allowedMimeTypes[contentType] {
-		// Special handling for HEIC as it might be detected as application/octet-stream
-		if ext == ".heic" {
-			// Additional HEIC validation by checking file signature
-			if !isValidHEIC(buffer) {
-				return fmt.Errorf("file content type %s does not match HEIC format", contentType)
-			}
-		} else {
-			return fmt.Errorf("file content type %s is not allowed. Only JPEG, PNG, HEIC, and WebP images are allowed", contentType)
-		}
+		return fmt.Errorf("file content type %s is not allowed. Only %s images are allowed", contentType, allowedContentTypes())
+	}
+
+	// The content must be of a type the extension allows
+	if err := checkExtensionContentType(ext, contentType); err != nil {
+		return err
 	}
 
 	// Verify magic bytes match the detected content type
@@ -108,7 +200,29 @@ func ValidateImageFile(file multipart.File, header *multipart.FileHeader) error
 		return errors.New("file content does not match its extension. Possible file manipulation detected")
 	}
 
-	return nil
+	// Reject oversized dimensions
+	return checkImageDimensions(file, buffer, contentType)
+}
+
+// detectImageContentType detects the content type of an image from its first
+// bytes. http.DetectContentType doesn't know HEIC, so that is checked by signature.
+func detectImageContentType(buffer []byte) string {
+	contentType := http.DetectContentType(buffer)
+	if contentType == "application/octet-stream" && isValidHEIC(buffer) {
+		return "image/heic"
+	}
+	return contentType
+}
+
+// checkExtensionContentType returns an error naming both sides when the content
+// type is not one the extension allows (e.g. JPEG data in a .png file)
+func checkExtensionContentType(ext, contentType string) error {
+	for _, allowed := range extensionContentTypes[ext] {
+		if allowed == contentType {
+			return nil
+		}
+	}
+	return fmt.Errorf("file extension %s does not match its content type %s", ext, contentType)
 }
 
 // verifyMagicBytes checks if the file's magic bytes match the expected format
@@ -125,6 +239,8 @@ func verifyMagicBytes(buffer []byte, contentType string, ext string) bool {
 		return len(buffer) >= 12 && 
 			buffer[0] == 0x52 && buffer[1] == 0x49 && buffer[2] == 0x46 && buffer[3] == 0x46 && // RIFF
 			buffer[8] == 0x57 && buffer[9] == 0x45 && buffer[10] == 0x42 && buffer[11] == 0x50 // WEBP
+	case "image/heic":
+		return isValidHEIC(buffer)
 	default:
 		// For HEIC and other formats
 		if ext == ".heic" {
@@ -160,91 +276,61 @@ func SaveImage(file multipart.File, header *multipart.FileHeader, subfolder stri
 		return nil, err
 	}
 
-	// Create upload directory if it doesn't exist
-	uploadPath := filepath.Join(UploadDir, subfolder)
-	if err := os.MkdirAll(uploadPath, 0755); err != nil {
-		return nil, errors.New("failed to create upload directory")
-	}
-
-	// Generate unique filename
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	filename := fmt.Sprintf("%s_%d%s", uuid.New().String(), time.Now().Unix(), ext)
-	fullPath := filepath.Join(uploadPath, filename)
-
-	// Create the file
-	dst, err := os.Create(fullPath)
-	if err != nil {
-		return nil, errors.New("failed to create file")
+	// Reject animated images where a static image is required
+	if RequiresStaticImage(subfolder) {
+		animated, err := IsAnimatedImage(file)
+		if err != nil {
+			return nil, err
+		}
+		if animated {
+			return nil, errors.New("animated images are not allowed here. Please upload a static image")
+		}
 	}
-	defer dst.Close()
 
-	// Copy the uploaded file to the destination
-	if _, err := io.Copy(dst, file); err != nil {
-		// Clean up the file if copy fails
-		os.Remove(fullPath)
-		return nil, errors.New("failed to save file")
+	// Detect the content type to store with the file
+	buffer := make([]byte, 512)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("failed to reset file pointer")
 	}
-
-	// Verify the saved file one more time
-	savedFile, err := os.Open(fullPath)
-	if err != nil {
-		os.Remove(fullPath)
-		return nil, errors.New("failed to verify saved file")
+	n, err := file.Read(buffer)
+	if err != nil && err != io.EOF {
+		return nil, errors.New("failed to read file")
 	}
-	defer savedFile.Close()
+	contentType := detectImageContentType(buffer[:n])
 
-	// Create a temporary header for validation
-	tempHeader := &multipart.FileHeader{
-		Filename: filename,
-		Size:     header.Size,
+	// Rewind so the whole file is stored
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("failed to reset file pointer")
 	}
 
-	if err := ValidateImageFile(savedFile, tempHeader); err != nil {
-		os.Remove(fullPath)
-		return nil, fmt.Errorf("saved file validation failed: %v", err)
-	}
+	// Generate unique filename
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	filename := fmt.Sprintf("%s_%d%s", uuid.New().String(), time.Now().Unix(), ext)
+	key := subfolder + "/" + filename
 
-	// Get file info
-	fileInfo, err := os.Stat(fullPath)
+	// Hand the file to the configured storage backend
+	imageURL, err := storage.Save(context.Background(), key, file, header.Size, contentType)
 	if err != nil {
-		return nil, errors.New("failed to get file info")
+		return nil, err
 	}
 
-	// Return relative URL path
-	imageURL := fmt.Sprintf("/uploads/%s/%s", subfolder, filename)
-
 	result := &ImageUploadResult{
 		Filename: filename,
-		Path:     fullPath,
+		Path:     key,
 		URL:      imageURL,
-		Size:     fileInfo.Size(),
+		Size:     header.Size,
 	}
 
 	return result, nil
 }
 
-// DeleteImage deletes an image file
+// DeleteImage deletes an image file from the configured storage backend
 func DeleteImage(imagePath string) error {
 	if imagePath == "" {
 		return nil
 	}
 
-	// Convert URL path to file system path
-	// e.g., /uploads/news/image.jpg -> ./uploads/news/image.jpg
-	filePath := strings.TrimPrefix(imagePath, "/")
-	filePath = filepath.Join(".", filePath)
-
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil // File doesn't exist, consider it as success
-	}
-
-	// Delete the file
-	if err := os.Remove(filePath); err != nil {
-		return errors.New("failed to delete image file")
-	}
-
-	return nil
+	return storage.Delete(context.Background(), imagePath)
 }
 
 // DeleteContentImages parses HTML content from a rich text editor (Quill) and
@@ -327,14 +413,17 @@ func ValidateImageBuffer(buffer []byte, filename string) error {
 	}
 
 	ext := strings.ToLower(filepath.Ext(filename))
-	if !allowedExtensions[ext] {
+	if extensionContentTypes[ext] == nil {
 		return fmt.Errorf("invalid file extension: %s", ext)
 	}
 
-	contentType := http.DetectContentType(buffer)
-	if !allowedMimeTypes[contentType] && ext != ".heic" {
+	contentType := detectImageContentType(buffer)
+	if !allowedMimeTypes[contentType] {
 		return fmt.Errorf("invalid content type: %s", contentType)
 	}
+	if err := checkExtensionContentType(ext, contentType); err != nil {
+		return err
+	}
 
 	if !verifyMagicBytes(buffer, contentType, ext) {
 		return errors.New("file content validation failed")
@@ -380,6 +469,11 @@ func PrependBaseURL(imageURL, baseURL string) string {
 	if imageURL == "" || baseURL == "" {
 		return imageURL
 	}
+
+	// Files in remote storage (S3) are already served from their own URL
+	if isRemoteStorageURL(imageURL) {
+		return imageURL
+	}
 	
 	// If the URL is a local upload path (contains /uploads/), 
 	// ensure it uses the current BASE_URL regardless of what's stored in DB
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"strings"
+	"testing"
+)
+
+// testJPEG returns a small valid JPEG image
+func testJPEG(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4)), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testHEIC returns the start of a HEIC file: an ftyp box with the heic brand
+func testHEIC() []byte {
+	data := make([]byte, 64)
+	copy(data[0:4], []byte{0x00, 0x00, 0x00, 0x18})
+	copy(data[4:12], "ftypheic")
+	return data
+}
+
+func TestValidateImageFileExtensions(t *testing.T) {
+	contents := map[string][]byte{
+		"image/jpeg": testJPEG(t),
+		"image/png":  testPNG(t, 4, 4),
+		"image/webp": testWebP(4, 4, false),
+		"image/heic": testHEIC(),
+	}
+
+	tests := []struct {
+		ext     string
+		matches string // The content type the extension allows
+	}{
+		{".jpg", "image/jpeg"},
+		{".jpeg", "image/jpeg"},
+		{".JPG", "image/jpeg"},
+		{".png", "image/png"},
+		{".webp", "image/webp"},
+		{".heic", "image/heic"},
+	}
+
+	for _, tt := range tests {
+		for contentType, data := range contents {
+			t.Run(tt.ext+"/"+contentType, func(t *testing.T) {
+				file, header := newTestUpload("photo"+tt.ext, data)
+				err := ValidateImageFile(file, header)
+
+				if contentType == tt.matches {
+					if err != nil {
+						t.Errorf("ValidateImageFile() error = %v, want nil", err)
+					}
+					return
+				}
+				if err == nil || !strings.Contains(err.Error(), "does not match its content type "+contentType) {
+					t.Errorf("ValidateImageFile() error = %v, want a mismatch with %s", err, contentType)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateImageFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     func(t *testing.T) []byte
+		wantErr  string // "" for a valid file
+	}{
+		{"valid png", "a.png", func(t *testing.T) []byte { return testPNG(t, 4, 4) }, ""},
+		{"empty", "a.png", func(*testing.T) []byte { return []byte{} }, "file is empty"},
+		{"gif extension", "a.gif", func(t *testing.T) []byte { return testGIF(t, 1) }, "file extension .gif is not allowed"},
+		{"gif content", "a.png", func(t *testing.T) []byte { return testGIF(t, 1) }, "content type image/gif is not allowed"},
+		{"text content", "a.jpg", func(*testing.T) []byte { return []byte("hello") }, "is not allowed"},
+		{"png at the maximum dimension", "a.png", func(t *testing.T) []byte { return testPNG(t, MaxImageDimension, 1) }, ""},
+		{"png too wide", "a.png", func(t *testing.T) []byte { return testPNG(t, MaxImageDimension+1, 1) }, "exceed the maximum"},
+		{"webp too tall", "a.webp", func(*testing.T) []byte { return testWebP(1, MaxImageDimension+1, false) }, "exceed the maximum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, header := newTestUpload(tt.filename, tt.data(t))
+			err := ValidateImageFile(file, header)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateImageFile() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateImageFile() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("too large", func(t *testing.T) {
+		file, header := newTestUpload("a.png", testPNG(t, 4, 4))
+		header.Size = MaxImageSize + 1
+		if err := ValidateImageFile(file, header); err == nil || !strings.Contains(err.Error(), "file size exceeds") {
+			t.Errorf("ValidateImageFile() error = %v, want a size error", err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Register JPEG decoder for image.DecodeConfig
+	_ "image/png"  // Register PNG decoder for image.DecodeConfig
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// MaxImageDimension is the largest width or height accepted for an image
+const MaxImageDimension = 10000
+
+// ImageInfo describes a validated image file
+type ImageInfo struct {
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width,omitempty"`  // 0 when the format can't be measured (HEIC)
+	Height      int    `json:"height,omitempty"` // 0 when the format can't be measured (HEIC)
+	Size        int64  `json:"size"`
+	Animated    bool   `json:"animated"`
+}
+
+// InspectImage runs ValidateImageFile on the file and returns its detected
+// type, dimensions and size. Nothing is written to disk.
+func InspectImage(file multipart.File, header *multipart.FileHeader) (*ImageInfo, error) {
+	if err := ValidateImageFile(file, header); err != nil {
+		return nil, err
+	}
+
+	// Read the header bytes again to detect the content type
+	buffer := make([]byte, 512)
+	n, err := file.Read(buffer)
+	if err != nil && err != io.EOF {
+		return nil, errors.New("failed to read file")
+	}
+	buffer = buffer[:n]
+	if _, err := file.Seek(0, 0); err != nil {
+		return nil, errors.New("failed to reset file pointer")
+	}
+
+	info := &ImageInfo{
+		ContentType: http.DetectContentType(buffer),
+		Size:        header.Size,
+	}
+	if strings.ToLower(filepath.Ext(header.Filename)) == ".heic" && isValidHEIC(buffer) {
+		info.ContentType = "image/heic"
+	}
+
+	// Measure dimensions; ValidateImageFile already checked them against the maximum
+	info.Width, info.Height, err = imageDimensions(file, buffer, info.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	animated, err := IsAnimatedImage(file)
+	if err != nil {
+		return nil, err
+	}
+	info.Animated = animated
+
+	return info, nil
+}
+
+// imageDimensions measures a JPEG, PNG or WebP image from its first bytes and
+// the file, which is rewound afterwards. Other formats (HEIC) measure 0x0.
+func imageDimensions(file multipart.File, buffer []byte, contentType string) (width, height int, err error) {
+	switch contentType {
+	case "image/jpeg", "image/png":
+		config, _, err := image.DecodeConfig(file)
+		if err != nil {
+			return 0, 0, errors.New("failed to read image dimensions")
+		}
+		if _, err := file.Seek(0, 0); err != nil {
+			return 0, 0, errors.New("failed to reset file pointer")
+		}
+		return config.Width, config.Height, nil
+	case "image/webp":
+		width, height, ok := webpDimensions(buffer)
+		if !ok {
+			return 0, 0, errors.New("failed to read image dimensions")
+		}
+		return width, height, nil
+	}
+	return 0, 0, nil
+}
+
+// checkImageDimensions rejects images wider or taller than MaxImageDimension
+func checkImageDimensions(file multipart.File, buffer []byte, contentType string) error {
+	width, height, err := imageDimensions(file, buffer, contentType)
+	if err != nil {
+		return err
+	}
+	if width > MaxImageDimension || height > MaxImageDimension {
+		return fmt.Errorf("image dimensions %dx%d exceed the maximum of %dx%d",
+			width, height, MaxImageDimension, MaxImageDimension)
+	}
+	return nil
+}
+
+// webpDimensions reads the canvas size from a WebP header (VP8, VP8L or VP8X chunk)
+func webpDimensions(buffer []byte) (width, height int, ok bool) {
+	if len(buffer) < 30 {
+		return 0, 0, false
+	}
+
+	switch string(buffer[12:16]) {
+	case "VP8 ":
+		// Lossy: 14-bit width and height after the frame start code
+		width = int(binary.LittleEndian.Uint16(buffer[26:28]) & 0x3fff)
+		height = int(binary.LittleEndian.Uint16(buffer[28:30]) & 0x3fff)
+	case "VP8L":
+		// Lossless: signature byte, then 14-bit width-1 and height-1
+		if buffer[20] != 0x2f {
+			return 0, 0, false
+		}
+		bits := binary.LittleEndian.Uint32(buffer[21:25])
+		width = int(bits&0x3fff) + 1
+		height = int((bits>>14)&0x3fff) + 1
+	case "VP8X":
+		// Extended: 24-bit canvas width-1 and height-1
+		width = int(uint32(buffer[24])|uint32(buffer[25])<<8|uint32(buffer[26])<<16) + 1
+		height = int(uint32(buffer[27])|uint32(buffer[28])<<8|uint32(buffer[29])<<16) + 1
+	default:
+		return 0, 0, false
+	}
+
+	return width, height, true
+}
@@ -1,43 +1,152 @@
 package utils
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log"
 	"time"
 
+	"sentul-golf-be/config"
+
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type Claims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// ImpersonatedBy is the ID of the admin acting as this user, if any
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateJWT creates a new JWT token
-func GenerateJWT(userID string, email, role, secret string) (string, error) {
+// Token lifetimes shared by token generation, validation and refresh
+var (
+	// TokenTTL is how long an issued access token stays valid
+	TokenTTL = 24 * time.Hour
+	// RefreshGrace is how long after expiry a token can still be refreshed
+	RefreshGrace = 1 * time.Hour
+	// ImpersonationTTL is how long an admin impersonation token stays valid
+	ImpersonationTTL = 15 * time.Minute
+)
+
+// jwtSecret signs and verifies every token. It is read once by LoadJWTConfig
+// so generation and validation can never use different secrets.
+var jwtSecret []byte
+
+// errNoJWTSecret is returned when tokens are used before a secret is loaded
+var errNoJWTSecret = errors.New("JWT secret is not loaded")
+
+// LoadJWTConfig reads the signing secret and the token lifetimes from the
+// environment. A missing or short secret is an error.
+func LoadJWTConfig() error {
+	secret := config.GetEnv("JWT_SECRET", "")
+	if len(secret) < config.MinJWTSecretLength {
+		return fmt.Errorf("JWT_SECRET must be at least %d characters", config.MinJWTSecretLength)
+	}
+	jwtSecret = []byte(secret)
+
+	if value := config.GetEnv("JWT_EXPIRY", ""); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			log.Printf("Warning: Invalid JWT_EXPIRY %q, using %s", value, TokenTTL)
+		} else {
+			TokenTTL = ttl
+		}
+	}
+
+	if value := config.GetEnv("JWT_REFRESH_GRACE", ""); value != "" {
+		grace, err := time.ParseDuration(value)
+		if err != nil || grace < 0 {
+			log.Printf("Warning: Invalid JWT_REFRESH_GRACE %q, using %s", value, RefreshGrace)
+		} else {
+			RefreshGrace = grace
+		}
+	}
+
+	if value := config.GetEnv("JWT_IMPERSONATION_TTL", ""); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			log.Printf("Warning: Invalid JWT_IMPERSONATION_TTL %q, using %s", value, ImpersonationTTL)
+		} else {
+			ImpersonationTTL = ttl
+		}
+	}
+
+	return nil
+}
+
+// GenerateJWT creates a new JWT token and returns it with its claims
+func GenerateJWT(userID string, email, role string) (string, *Claims, error) {
 	claims := &Claims{
 		UserID: userID,
 		Email:  email,
 		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+	}
+	token, _, err := signJWT(claims, TokenTTL)
+	return token, claims, err
+}
+
+// GenerateImpersonationJWT creates a short-lived token that lets the admin
+// adminID act as the given user
+func GenerateImpersonationJWT(userID, email, role, adminID string) (string, time.Time, error) {
+	return signJWT(&Claims{
+		UserID:         userID,
+		Email:          email,
+		Role:           role,
+		ImpersonatedBy: adminID,
+	}, ImpersonationTTL)
+}
+
+// signJWT sets the registered claims and signs the token
+func signJWT(claims *Claims, ttl time.Duration) (string, time.Time, error) {
+	if len(jwtSecret) == 0 {
+		return "", time.Time{}, errNoJWTSecret
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.NewString(), // JTI, used to revoke the token
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(now),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	signed, err := token.SignedString(jwtSecret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
 }
 
 // ValidateJWT validates and parses a JWT token
-func ValidateJWT(tokenString, secret string) (*Claims, error) {
+func ValidateJWT(tokenString string) (*Claims, error) {
+	return parseJWT(tokenString)
+}
+
+// ValidateJWTForRefresh validates a token like ValidateJWT, but also accepts
+// tokens that expired less than RefreshGrace ago
+func ValidateJWTForRefresh(tokenString string) (*Claims, error) {
+	return parseJWT(tokenString, jwt.WithLeeway(RefreshGrace))
+}
+
+// parseJWT parses and verifies a token signed with the loaded secret
+func parseJWT(tokenString string, options ...jwt.ParserOption) (*Claims, error) {
+	if len(jwtSecret) == 0 {
+		return nil, errNoJWTSecret
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
 		}
-		return []byte(secret), nil
-	})
+		return jwtSecret, nil
+	}, options...)
 
 	if err != nil {
 		return nil, err
@@ -49,3 +158,34 @@ func ValidateJWT(tokenString, secret string) (*Claims, error) {
 
 	return nil, errors.New("invalid token")
 }
+
+// revokedTokenKey returns the Redis key marking a token ID as revoked
+func revokedTokenKey(jti string) string {
+	return BuildCacheKey("auth", "revoked", jti)
+}
+
+// RevokeToken blacklists a token until it can no longer be used, which is its
+// expiry plus the refresh grace window. Does nothing when Redis is unavailable.
+func RevokeToken(ctx context.Context, claims *Claims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	ttl := time.Until(claims.ExpiresAt.Time) + RefreshGrace
+	if ttl <= 0 {
+		return nil
+	}
+
+	return CacheSet(ctx, revokedTokenKey(claims.ID), true, ttl)
+}
+
+// IsTokenRevoked reports whether a token ID has been revoked.
+// Tokens are treated as valid when Redis is unavailable.
+func IsTokenRevoked(ctx context.Context, jti string) bool {
+	if jti == "" || !IsRedisAvailable() {
+		return false
+	}
+
+	n, err := config.GetRedis().Exists(ctx, revokedTokenKey(jti)).Result()
+	return err == nil && n > 0
+}
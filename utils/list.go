@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"sentul-golf-be/config"
+)
+
+// Response styles for list endpoints
+const (
+	ResponseStyleWrapped = "wrapped" // {"status","data":{key:[...]},"meta"}
+	ResponseStyleBare    = "bare"    // [...] with X-Total-Count and Link headers
+)
+
+// ResponseStyle returns the list response style requested by the client via
+// the X-Response-Style header, falling back to RESPONSE_STYLE (default wrapped)
+func ResponseStyle(r *http.Request) string {
+	style := strings.ToLower(r.Header.Get("X-Response-Style"))
+	if style == "" {
+		style = strings.ToLower(config.GetEnv("RESPONSE_STYLE", ResponseStyleWrapped))
+	}
+	if style == ResponseStyleBare {
+		return ResponseStyleBare
+	}
+	return ResponseStyleWrapped
+}
+
+// RespondList sends a list response in the requested style. In the wrapped
+// style items are placed under key in data (or used as data directly when key
+// is empty); in the bare style the body is the items array and pagination is
+// sent as X-Total-Count and Link headers.
+func RespondList(w http.ResponseWriter, r *http.Request, key string, items interface{}, meta *Meta) {
+	respondList(w, r, key, items, meta, false)
+}
+
+// RespondListETag is RespondList with a weak ETag, answering a matching
+// If-None-Match with 304 Not Modified
+func RespondListETag(w http.ResponseWriter, r *http.Request, key string, items interface{}, meta *Meta) {
+	respondList(w, r, key, items, meta, true)
+}
+
+func respondList(w http.ResponseWriter, r *http.Request, key string, items interface{}, meta *Meta, etag bool) {
+	if ResponseStyle(r) != ResponseStyleBare {
+		var data interface{} = items
+		if key != "" {
+			data = map[string]interface{}{key: items}
+		}
+		if etag {
+			RespondSuccessETag(w, r, data, meta)
+			return
+		}
+		RespondSuccess(w, http.StatusOK, data, meta)
+		return
+	}
+
+	// Never encode a nil slice as null
+	if v := reflect.ValueOf(items); items == nil || (v.Kind() == reflect.Slice && v.IsNil()) {
+		items = []interface{}{}
+	}
+
+	total := 0
+	if meta != nil {
+		total = meta.Total
+		if link := paginationLinks(r, meta); link != "" {
+			w.Header().Set("Link", link)
+		}
+	} else if v := reflect.ValueOf(items); v.Kind() == reflect.Slice {
+		total = v.Len()
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if etag {
+		writeJSONWithETag(w, r, items)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(items)
+}
+
+// paginationLinks builds an RFC 8288 Link header with first, prev, next and last pages
+func paginationLinks(r *http.Request, meta *Meta) string {
+	if meta.TotalPages == 0 {
+		return ""
+	}
+
+	pageURL := func(page int) string {
+		u := *r.URL
+		query := u.Query()
+		query.Set("page", strconv.Itoa(page))
+		if meta.Limit > 0 {
+			query.Set("limit", strconv.Itoa(meta.Limit))
+		}
+		u.RawQuery = query.Encode()
+		return u.RequestURI()
+	}
+
+	links := []string{fmt.Sprintf(`<%s>; rel="first"`, pageURL(1))}
+	if meta.Page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(meta.Page-1)))
+	}
+	if meta.Page < meta.TotalPages {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(meta.Page+1)))
+	}
+	links = append(links, fmt.Sprintf(`<%s>; rel="last"`, pageURL(meta.TotalPages)))
+
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"sentul-golf-be/config"
+)
+
+// Mailer delivers plain text emails
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// mailer is the active mail backend, log-only unless InitMailer selects another
+var mailer Mailer = logMailer{}
+
+// InitMailer selects the mail backend from MAIL_DRIVER (log or smtp)
+func InitMailer() error {
+	switch driver := config.GetEnv("MAIL_DRIVER", "log"); driver {
+	case "log":
+		mailer = logMailer{}
+		return nil
+	case "smtp":
+		m, err := newSMTPMailer()
+		if err != nil {
+			return err
+		}
+		mailer = m
+		return nil
+	default:
+		return fmt.Errorf("unknown MAIL_DRIVER %q", driver)
+	}
+}
+
+// SendMail sends an email through the active mail backend
+func SendMail(ctx context.Context, to, subject, body string) error {
+	return mailer.Send(ctx, to, subject, body)
+}
+
+// logMailer writes emails to the application log instead of sending them,
+// for development
+type logMailer struct{}
+
+func (logMailer) Send(ctx context.Context, to, subject, body string) error {
+	log.Printf("MAIL: to=%s subject=%q\n%s", to, subject, body)
+	return nil
+}
+
+// smtpMailer sends emails through an SMTP server
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// newSMTPMailer creates the SMTP backend from the SMTP_* environment variables
+func newSMTPMailer() (*smtpMailer, error) {
+	host := config.GetEnv("SMTP_HOST", "")
+	from := config.GetEnv("SMTP_FROM", "")
+	if host == "" || from == "" {
+		return nil, errors.New("SMTP_HOST and SMTP_FROM are required for the smtp mail driver")
+	}
+
+	m := &smtpMailer{
+		addr: net.JoinHostPort(host, config.GetEnv("SMTP_PORT", "587")),
+		from: from,
+	}
+	if username := config.GetEnv("SMTP_USERNAME", ""); username != "" {
+		m.auth = smtp.PlainAuth("", username, config.GetEnv("SMTP_PASSWORD", ""), host)
+	}
+	return m, nil
+}
+
+func (m *smtpMailer) Send(ctx context.Context, to, subject, body string) error {
+	// Keep header values on one line
+	clean := strings.NewReplacer("\r", "", "\n", "")
+	msg := "From: " + m.from + "\r\n" +
+		"To: " + clean.Replace(to) + "\r\n" +
+		"Subject: " + clean.Replace(subject) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg))
+}
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/PuerkitoBio/goquery"
+)
+
+// HTMLToMarkdown converts sanitized HTML content to CommonMark. Local upload
+// links and images are made absolute with baseURL so the output works
+// outside this site.
+func HTMLToMarkdown(html, baseURL string) (string, error) {
+	converter := md.NewConverter("", true, &md.Options{
+		GetAbsoluteURL: func(_ *goquery.Selection, rawURL string, _ string) string {
+			return PrependBaseURL(rawURL, baseURL)
+		},
+	})
+	return converter.ConvertString(html)
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"sentul-golf-be/config"
+)
+
+// uploadSweepFolders are the upload subfolders whose files are referenced
+// from the database and can therefore be swept
+var uploadSweepFolders = []string{"news", "events", "holes", "amenities", "content"}
+
+// OrphanSweepReport summarises a sweep of unreferenced uploads
+type OrphanSweepReport struct {
+	Scanned int      `json:"scanned"`
+	Orphans []string `json:"orphans"` // Keys of unreferenced files, relative to the uploads root
+	Deleted int      `json:"deleted"`
+	DryRun  bool     `json:"dry_run"`
+}
+
+// OrphanUploadMinAge returns how old an unreferenced file must be before it
+// is swept, from UPLOAD_ORPHAN_MIN_AGE (default 24h). Content images are
+// uploaded before the article that embeds them is saved, so fresh files are
+// never treated as orphans.
+func OrphanUploadMinAge() time.Duration {
+	if d, err := time.ParseDuration(config.GetEnv("UPLOAD_ORPHAN_MIN_AGE", "")); err == nil && d > 0 {
+		return d
+	}
+	return 24 * time.Hour
+}
+
+// referencedUploadKeys collects the storage keys of every file the database
+// still points at, soft-deleted rows included since they can be restored
+func referencedUploadKeys(ctx context.Context) (map[string]bool, error) {
+	db := config.GetDB().WithContext(ctx).Unscoped()
+	keys := make(map[string]bool)
+	add := func(fileURL string) {
+		if key := storageKey(fileURL); key != "" {
+			keys[key] = true
+		}
+	}
+
+	// Thumbnails and gallery images
+	for _, source := range []struct{ table, column string }{
+		{"news", "image_url"},
+		{"events", "image_url"},
+		{"holes", "image_url"},
+		{"amenities", "image_url"},
+		{"images", "url"},
+	} {
+		var urls []string
+		if err := db.Table(source.table).Where(source.column+" <> ''").Pluck(source.column, &urls).Error; err != nil {
+			return nil, err
+		}
+		for _, fileURL := range urls {
+			add(fileURL)
+		}
+	}
+
+	// Inline images embedded in news and event content
+	for _, table := range []string{"news", "events"} {
+		var contents []string
+		if err := db.Table(table).Pluck("content", &contents).Error; err != nil {
+			return nil, err
+		}
+		for _, content := range contents {
+			for imagePath := range extractContentImagePaths(content) {
+				add(imagePath)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// SweepOrphanUploads finds uploaded files that no database row references
+// and are older than minAge, and deletes them unless dryRun is set
+func SweepOrphanUploads(ctx context.Context, minAge time.Duration, dryRun bool) (*OrphanSweepReport, error) {
+	referenced, err := referencedUploadKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OrphanSweepReport{Orphans: []string{}, DryRun: dryRun}
+	cutoff := time.Now().Add(-minAge)
+	for _, folder := range uploadSweepFolders {
+		objects, err := storage.List(ctx, folder+"/")
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			report.Scanned++
+			if referenced[object.Key] || object.ModTime.After(cutoff) {
+				continue
+			}
+			report.Orphans = append(report.Orphans, object.Key)
+			if dryRun {
+				continue
+			}
+			if err := storage.Delete(ctx, storage.URL(object.Key)); err != nil {
+				log.Printf("Warning: Failed to delete orphaned upload %s: %v", object.Key, err)
+				continue
+			}
+			report.Deleted++
+		}
+	}
+
+	return report, nil
+}
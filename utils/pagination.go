@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Pagination limits shared by all list endpoints
+const (
+	DefaultPageLimit = 10
+	MaxPageLimit     = 100
+)
+
+// ParsePagination reads the page and limit query parameters.
+// Invalid or missing values fall back to page 1 and defaultLimit.
+func ParsePagination(r *http.Request, defaultLimit int) (page, limit, offset int) {
+	page = 1
+	limit = defaultLimit
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= MaxPageLimit {
+			limit = l
+		}
+	}
+
+	offset = (page - 1) * limit
+	return page, limit, offset
+}
+
+// NewMeta builds pagination metadata for a list response
+func NewMeta(page, limit int, total int64) *Meta {
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	return &Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+	}
+}
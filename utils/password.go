@@ -1,9 +1,45 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
+	"unicode"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// MinPasswordLength is the minimum accepted length for a new password
+const MinPasswordLength = 8
+
+// maxPasswordBytes is the longest password bcrypt can hash
+const maxPasswordBytes = 72
+
+// ValidatePassword checks a new password against the password policy: at
+// least MinPasswordLength characters with at least one letter and one digit.
+// The error reads as the end of a sentence, e.g. "Password " + err.Error().
+func ValidatePassword(password string) error {
+	if len([]rune(password)) < MinPasswordLength {
+		return fmt.Errorf("must be at least %d characters", MinPasswordLength)
+	}
+	if len(password) > maxPasswordBytes {
+		return fmt.Errorf("must be at most %d bytes", maxPasswordBytes)
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return errors.New("must contain at least one letter and one digit")
+	}
+	return nil
+}
+
 // HashPassword hashes a password using bcrypt
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"sentul-golf-be/config"
+)
+
+// ErrInvalidResetToken is returned for unknown, used or expired reset tokens
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+
+// PasswordResetTTL returns how long a password reset token stays valid, from
+// PASSWORD_RESET_TTL (default 30m)
+func PasswordResetTTL() time.Duration {
+	if d, err := time.ParseDuration(config.GetEnv("PASSWORD_RESET_TTL", "")); err == nil && d > 0 {
+		return d
+	}
+	return 30 * time.Minute
+}
+
+// passwordResetKey returns the Redis key of a reset token. Only a hash of the
+// token is stored so the keys can't be used to reset passwords.
+func passwordResetKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return BuildCacheKey("auth", "reset", hex.EncodeToString(sum[:]))
+}
+
+// CreatePasswordResetToken issues a single-use password reset token for a user
+func CreatePasswordResetToken(ctx context.Context, userID string) (string, error) {
+	if !IsRedisAvailable() {
+		return "", errors.New("redis not available")
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	if err := CacheSet(ctx, passwordResetKey(token), userID, PasswordResetTTL()); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ConsumePasswordResetToken returns the user a reset token was issued for and
+// invalidates the token
+func ConsumePasswordResetToken(ctx context.Context, token string) (string, error) {
+	if !IsRedisAvailable() {
+		return "", errors.New("redis not available")
+	}
+
+	// GETDEL makes the token single-use even under concurrent requests
+	val, err := config.GetRedis().GetDel(ctx, passwordResetKey(token)).Result()
+	if err != nil {
+		return "", ErrInvalidResetToken
+	}
+
+	var userID string
+	if err := json.Unmarshal([]byte(val), &userID); err != nil || userID == "" {
+		return "", ErrInvalidResetToken
+	}
+	return userID, nil
+}
@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"sentul-golf-be/config"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// scheduledPublishTables maps entities with scheduled publishing to their tables
+var scheduledPublishTables = map[string]string{
+	"news":  "news",
+	"event": "events",
+}
+
+// scheduledPublishDue matches unpublished rows whose publish time has passed
+const scheduledPublishDue = "published = ? AND publish_at IS NOT NULL AND publish_at <= ? AND deleted_at IS NULL"
+
+// requireImageEnv maps entities to the setting that makes an image mandatory for publishing
+var requireImageEnv = map[string]string{
+	"news":  "REQUIRE_NEWS_IMAGE_TO_PUBLISH",
+	"event": "REQUIRE_EVENT_IMAGE_TO_PUBLISH",
+}
+
+// RequireImageToPublish reports whether a news article or event ("news" or
+// "event") needs an image before it can be published. Opt-in per entity.
+func RequireImageToPublish(entity string) bool {
+	return config.GetEnv(requireImageEnv[entity], "false") == "true"
+}
+
+// PublishScheduledContent publishes the news and events whose publish_at has
+// passed, invalidates the affected caches and notifies the webhooks. The
+// schedule moves to published_at, so an item unpublished later isn't picked up
+// again. Items without an image are skipped while RequireImageToPublish is on.
+func PublishScheduledContent(ctx context.Context) error {
+	db := config.GetDB()
+	now := time.Now()
+
+	for entity, table := range scheduledPublishTables {
+		var rows []struct {
+			ID    string
+			Slug  string
+			Title string
+		}
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// Lock the due rows so a concurrent edit can't be overwritten
+			query := tx.Table(table).Select("id, slug, title").Where(scheduledPublishDue, false, now)
+			if RequireImageToPublish(entity) {
+				// Imageless items stay scheduled until an image is added
+				query = query.Where("image_url <> ''")
+			}
+			if err := query.Clauses(clause.Locking{Strength: "UPDATE"}).Scan(&rows).Error; err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return nil
+			}
+
+			ids := make([]string, len(rows))
+			for i, row := range rows {
+				ids[i] = row.ID
+			}
+			return tx.Table(table).Where("id IN ?", ids).Updates(map[string]interface{}{
+				"published":    true,
+				"published_at": gorm.Expr("publish_at"),
+				"publish_at":   nil,
+				"updated_at":   now,
+			}).Error
+		})
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		// Invalidate caches of the newly visible content
+		ids := make([]string, len(rows))
+		slugs := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i], slugs[i] = row.ID, row.Slug
+		}
+		InvalidateContentCaches(ctx, entity, ids, slugs)
+		for _, row := range rows {
+			NotifyPublished(entity, row.ID, row.Slug, row.Title)
+		}
+		log.Printf("Published %d scheduled %s item(s)", len(rows), entity)
+	}
+
+	return nil
+}
+
+// publisherWake tells the scheduled publisher to recompute its next wake time
+var publisherWake = make(chan struct{}, 1)
+
+// WakeScheduledPublisher makes the scheduled publisher re-check its schedule,
+// e.g. after a publish_at was set or changed
+func WakeScheduledPublisher() {
+	select {
+	case publisherWake <- struct{}{}:
+	default: // A wake-up is already pending
+	}
+}
+
+// nextScheduledPublish returns the earliest publish_at still in the future
+func nextScheduledPublish(ctx context.Context) (time.Time, bool) {
+	db := config.GetDB().WithContext(ctx)
+	now := time.Now()
+
+	var next time.Time
+	found := false
+	for _, table := range scheduledPublishTables {
+		var earliest *time.Time
+		if err := db.Table(table).
+			Where("published = ? AND publish_at > ? AND deleted_at IS NULL", false, now).
+			Select("MIN(publish_at)").Scan(&earliest).Error; err != nil || earliest == nil {
+			continue
+		}
+		if !found || earliest.Before(next) {
+			next, found = *earliest, true
+		}
+	}
+	return next, found
+}
+
+// StartScheduledPublisher publishes scheduled content in the background. It
+// sleeps until the nearest publish_at, or at most maxInterval so schedules
+// made elsewhere (e.g. by another instance) are still picked up.
+func StartScheduledPublisher(maxInterval time.Duration) {
+	go func() {
+		for {
+			ctx := context.Background()
+			if err := PublishScheduledContent(ctx); err != nil {
+				log.Printf("Warning: Failed to publish scheduled content: %v", err)
+			}
+
+			wait := maxInterval
+			if next, ok := nextScheduledPublish(ctx); ok && time.Until(next) < wait {
+				wait = time.Until(next)
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-publisherWake:
+				timer.Stop()
+			}
+		}
+	}()
+}
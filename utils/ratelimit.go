@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"sentul-golf-be/config"
+)
+
+// RateLimitResult describes a rate limit after a request was counted
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration // Time until the current window ends
+}
+
+// HitRateLimit counts a request against key in a fixed window and reports
+// whether it is within limit. Requests are always allowed when Redis is
+// unavailable or fails, so rate limiting never takes the API down.
+func HitRateLimit(ctx context.Context, key string, limit int, window time.Duration) RateLimitResult {
+	allowed := RateLimitResult{Allowed: true, Limit: limit, Remaining: limit, Reset: window}
+	if !IsRedisAvailable() {
+		return allowed
+	}
+
+	client := config.GetRedis()
+	key = BuildCacheKey("ratelimit", key)
+
+	count, err := client.Incr(ctx, key).Result()
+	if err != nil {
+		return allowed
+	}
+
+	// Start the window on the first hit (or if an earlier expire was lost)
+	ttl, err := client.PTTL(ctx, key).Result()
+	if err != nil {
+		return allowed
+	}
+	if count == 1 || ttl < 0 {
+		client.PExpire(ctx, key, window)
+		ttl = window
+	}
+
+	remaining := limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return RateLimitResult{
+		Allowed:   int(count) <= limit,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     ttl,
+	}
+}
+
+// SetRateLimitHeaders writes the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers for limit, plus Retry-After when it was exceeded
+func SetRateLimitHeaders(w http.ResponseWriter, limit RateLimitResult) {
+	reset := strconv.Itoa(int(math.Ceil(limit.Reset.Seconds())))
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(limit.Remaining))
+	w.Header().Set("RateLimit-Reset", reset)
+	if !limit.Allowed {
+		w.Header().Set("Retry-After", reset)
+	}
+}
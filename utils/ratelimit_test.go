@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetRateLimitHeaders(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          RateLimitResult
+		wantRemaining  string
+		wantReset      string
+		wantRetryAfter string
+	}{
+		{
+			name:          "allowed",
+			limit:         RateLimitResult{Allowed: true, Limit: 10, Remaining: 7, Reset: 42 * time.Second},
+			wantRemaining: "7",
+			wantReset:     "42",
+		},
+		{
+			name:          "reset rounds up",
+			limit:         RateLimitResult{Allowed: true, Limit: 10, Remaining: 9, Reset: 1500 * time.Millisecond},
+			wantRemaining: "9",
+			wantReset:     "2",
+		},
+		{
+			name:           "exceeded",
+			limit:          RateLimitResult{Allowed: false, Limit: 10, Remaining: 0, Reset: 30 * time.Second},
+			wantRemaining:  "0",
+			wantReset:      "30",
+			wantRetryAfter: "30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			SetRateLimitHeaders(w, tt.limit)
+
+			headers := map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": tt.wantRemaining,
+				"RateLimit-Reset":     tt.wantReset,
+				"Retry-After":         tt.wantRetryAfter,
+			}
+			for name, want := range headers {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHitRateLimit(t *testing.T) {
+	setupTestRedis(t)
+	ctx := context.Background()
+
+	for hit := 1; hit <= 4; hit++ {
+		limit := HitRateLimit(ctx, "test", 3, time.Minute)
+		if wantAllowed := hit <= 3; limit.Allowed != wantAllowed {
+			t.Errorf("hit %d: Allowed = %v, want %v", hit, limit.Allowed, wantAllowed)
+		}
+		if wantRemaining := max(3-hit, 0); limit.Remaining != wantRemaining {
+			t.Errorf("hit %d: Remaining = %d, want %d", hit, limit.Remaining, wantRemaining)
+		}
+		if limit.Reset <= 0 || limit.Reset > time.Minute {
+			t.Errorf("hit %d: Reset = %v, want within the window", hit, limit.Reset)
+		}
+	}
+}
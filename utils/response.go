@@ -2,7 +2,9 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 )
 
 // SuccessResponse represents a successful API response
@@ -31,6 +33,22 @@ type Meta struct {
 	TotalPages int `json:"total_pages,omitempty"`
 	Total      int `json:"total,omitempty"`
 	Limit      int `json:"limit,omitempty"`
+	// Published/draft breakdown of Total, only set for admin content lists
+	PublishedCount *int `json:"published_count,omitempty"`
+	DraftCount     *int `json:"draft_count,omitempty"`
+}
+
+// SetCacheControl lets browsers and CDNs cache a public response for maxAge.
+// Requests carrying a token may get drafts or admin-only fields, so their
+// responses are marked no-store instead. Handlers opt in by calling it just
+// before a successful response.
+func SetCacheControl(w http.ResponseWriter, r *http.Request, maxAge time.Duration) {
+	w.Header().Add("Vary", "Authorization")
+	if r.Header.Get("Authorization") != "" {
+		w.Header().Set("Cache-Control", "no-store")
+		return
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
 }
 
 // RespondSuccess sends a successful JSON response
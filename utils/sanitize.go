@@ -1,18 +1,39 @@
 package utils
 
 import (
+	"log"
+	"net/url"
 	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"sentul-golf-be/config"
 
 	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
-// SanitizeHTML sanitizes HTML content to prevent XSS attacks
-// while allowing safe formatting tags commonly used by rich text editors like React Quill
-func SanitizeHTML(html string) string {
+// sanitizePolicy returns the policy used by SanitizeHTML. It is built from the
+// environment on first use and shared afterwards: a bluemonday policy is safe
+// for concurrent use once constructed.
+var sanitizePolicy = sync.OnceValue(buildSanitizePolicy)
+
+// buildSanitizePolicy creates the base UGC policy with Quill support and
+// any extra tags/attributes configured through the environment.
+//
+// Supported settings (all optional, layered on top of the UGC policy):
+//   - SANITIZE_ALLOW_IFRAMES=true       allow <iframe> embeds (maps, videos) over https
+//   - SANITIZE_IFRAME_HOSTS=a.com,b.com  restrict iframe src to these hosts
+//   - SANITIZE_EXTRA_ELEMENTS=figure,figcaption
+//   - SANITIZE_EXTRA_ATTRIBUTES=target:a,data-id:div|span,title
+//     (attr:element1|element2, or a bare attr to allow it on every element)
+func buildSanitizePolicy() *bluemonday.Policy {
 	// Use UGC (User Generated Content) policy which allows common formatting
 	// but blocks dangerous elements and attributes
 	policy := bluemonday.UGCPolicy()
-	
+
 	// The UGC policy already allows most React Quill tags:
 	// - Headings: h1-h6
 	// - Paragraphs: p
@@ -24,13 +45,196 @@ func SanitizeHTML(html string) string {
 	// - Code: code, pre
 	// - Line breaks: br
 	// - Tables: table, thead, tbody, tr, th, td
-	
+
 	// Allow Quill text alignment and indentation classes (e.g. ql-align-justify, ql-indent-1)
 	// It allows multiple classes separated by spaces as long as they all start with ql-
 	policy.AllowAttrs("class").Matching(
 		regexp.MustCompile(`^(?:\s*ql-[a-zA-Z0-9\-]+\s*)+$`),
 	).OnElements("p", "h1", "h2", "h3", "h4", "h5", "h6", "li")
 
-	// Sanitize and return
-	return policy.Sanitize(html)
+	// Optional iframe embeds (e.g. Google Maps, YouTube)
+	if config.GetEnv("SANITIZE_ALLOW_IFRAMES", "false") == "true" {
+		srcPattern := `^https://[^\s"]+$`
+		if hosts := splitEnvList("SANITIZE_IFRAME_HOSTS"); len(hosts) > 0 {
+			quoted := make([]string, len(hosts))
+			for i, host := range hosts {
+				quoted[i] = regexp.QuoteMeta(host)
+			}
+			srcPattern = `^https://(?:` + strings.Join(quoted, "|") + `)(?:[/?#][^\s"]*)?$`
+		}
+
+		policy.AllowElements("iframe")
+		policy.AllowAttrs("src").Matching(regexp.MustCompile(srcPattern)).OnElements("iframe")
+		policy.AllowAttrs("width", "height").Matching(regexp.MustCompile(`^[0-9]+%?$`)).OnElements("iframe")
+		policy.AllowAttrs("frameborder", "allowfullscreen", "allow", "loading", "title").OnElements("iframe")
+	}
+
+	// Extra elements allowed without attributes
+	if elements := splitEnvList("SANITIZE_EXTRA_ELEMENTS"); len(elements) > 0 {
+		policy.AllowElements(elements...)
+	}
+
+	// Extra attributes, either per element ("attr:el1|el2") or global ("attr")
+	for _, entry := range splitEnvList("SANITIZE_EXTRA_ATTRIBUTES") {
+		attr, elements, scoped := strings.Cut(entry, ":")
+		attr = strings.TrimSpace(attr)
+		if attr == "" {
+			continue
+		}
+
+		// Never allow event handler attributes, even if configured
+		if strings.HasPrefix(strings.ToLower(attr), "on") {
+			log.Printf("Warning: ignoring unsafe sanitize attribute %q", attr)
+			continue
+		}
+
+		if !scoped {
+			policy.AllowAttrs(attr).Globally()
+			continue
+		}
+
+		var names []string
+		for _, el := range strings.Split(elements, "|") {
+			if el = strings.TrimSpace(el); el != "" {
+				names = append(names, el)
+			}
+		}
+		if len(names) > 0 {
+			policy.AllowAttrs(attr).OnElements(names...)
+		}
+	}
+
+	return policy
+}
+
+// splitEnvList reads a comma-separated environment variable into a trimmed list
+func splitEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(config.GetEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// SanitizeHTML sanitizes HTML content to prevent XSS attacks
+// while allowing safe formatting tags commonly used by rich text editors like React Quill
+func SanitizeHTML(html string) string {
+	// Sanitize, then drop images from hosts that aren't allowed
+	return restrictContentImages(sanitizePolicy().Sanitize(html), contentImageHosts())
+}
+
+// contentImageHosts returns the hosts content images may be loaded from, or
+// nil when SANITIZE_RESTRICT_IMAGES is off. Like the policy it is built on
+// first use, which comes after the storage backend is set up.
+var contentImageHosts = sync.OnceValue(buildContentImageHosts)
+
+// buildContentImageHosts collects the BASE_URL and storage hosts and the
+// hosts listed in SANITIZE_IMAGE_HOSTS
+func buildContentImageHosts() map[string]bool {
+	if config.GetEnv("SANITIZE_RESTRICT_IMAGES", "false") != "true" {
+		return nil
+	}
+
+	hosts := make(map[string]bool)
+	if base, err := url.Parse(config.GetEnv("BASE_URL", "")); err == nil && base.Host != "" {
+		hosts[strings.ToLower(base.Host)] = true
+	}
+	if remote, err := url.Parse(remoteStorageURL); err == nil && remote.Host != "" {
+		hosts[strings.ToLower(remote.Host)] = true
+	}
+	for _, host := range splitEnvList("SANITIZE_IMAGE_HOSTS") {
+		hosts[strings.ToLower(host)] = true
+	}
+	return hosts
+}
+
+// restrictContentImages removes img tags whose src doesn't point at our own
+// uploads or one of hosts. This blocks tracking pixels and mixed content.
+// The sanitized HTML is walked with the HTML tokenizer and every other token
+// is copied through unchanged. A nil hosts leaves the content as it is.
+//
+// bluemonday's URL scheme policies can't do this: they apply to every URL
+// attribute, so restricting https hosts there would break outgoing links.
+func restrictContentImages(content string, hosts map[string]bool) string {
+	if hosts == nil {
+		return content
+	}
+
+	var out strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return out.String()
+		}
+		// Copy the raw text first; reading the token may change the buffer
+		raw := string(tokenizer.Raw())
+
+		if tokenType == html.StartTagToken || tokenType == html.SelfClosingTagToken {
+			token := tokenizer.Token()
+			if token.DataAtom == atom.Img && !allowedContentImage(token, hosts) {
+				continue
+			}
+		}
+		out.WriteString(raw)
+	}
+}
+
+// allowedContentImage reports whether an img tag's src is a relative path
+// below /uploads/ or a URL on one of hosts. An img without a src is kept.
+func allowedContentImage(token html.Token, hosts map[string]bool) bool {
+	for _, attr := range token.Attr {
+		if attr.Key != "src" {
+			continue
+		}
+		src, err := url.Parse(attr.Val)
+		if err != nil {
+			return false
+		}
+		if src.Host == "" && src.Scheme == "" {
+			return strings.HasPrefix(src.Path, "/uploads/")
+		}
+		return hosts[strings.ToLower(src.Host)]
+	}
+	return true
+}
+
+// htmlTagPattern matches opening tags and captures the tag name
+var htmlTagPattern = regexp.MustCompile(`<\s*([a-zA-Z][a-zA-Z0-9]*)`)
+
+// RemovedTag describes an HTML tag that was (partially) stripped by the sanitizer
+type RemovedTag struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// countTags counts opening tags by (lowercased) name
+func countTags(html string) map[string]int {
+	counts := make(map[string]int)
+	for _, match := range htmlTagPattern.FindAllStringSubmatch(html, -1) {
+		counts[strings.ToLower(match[1])]++
+	}
+	return counts
+}
+
+// DiffRemovedTags compares the tag sets of the original and sanitized HTML
+// and returns the tags that the sanitizer removed, sorted by tag name
+func DiffRemovedTags(original, sanitized string) []RemovedTag {
+	before := countTags(original)
+	after := countTags(sanitized)
+
+	removed := []RemovedTag{}
+	for tag, count := range before {
+		if diff := count - after[tag]; diff > 0 {
+			removed = append(removed, RemovedTag{Tag: tag, Count: diff})
+		}
+	}
+
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].Tag < removed[j].Tag
+	})
+
+	return removed
 }
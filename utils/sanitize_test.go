@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildSanitizePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		input    string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "default policy strips iframes",
+			input:    `<p>Map</p><iframe src="https://maps.google.com/embed"></iframe>`,
+			contains: []string{"<p>Map</p>"},
+			excludes: []string{"<iframe"},
+		},
+		{
+			name:     "default policy keeps quill classes",
+			input:    `<p class="ql-align-center ql-indent-1">Centered</p>`,
+			contains: []string{`class="ql-align-center ql-indent-1"`},
+		},
+		{
+			name:     "default policy drops other classes",
+			input:    `<p class="evil">Text</p>`,
+			contains: []string{"<p>Text</p>"},
+		},
+		{
+			name:     "iframes allowed over https",
+			env:      map[string]string{"SANITIZE_ALLOW_IFRAMES": "true"},
+			input:    `<iframe src="https://www.youtube.com/embed/abc" width="560" height="315" allowfullscreen></iframe>`,
+			contains: []string{`<iframe src="https://www.youtube.com/embed/abc"`, `width="560"`, `height="315"`, "allowfullscreen"},
+		},
+		{
+			name:     "iframe src must be https",
+			env:      map[string]string{"SANITIZE_ALLOW_IFRAMES": "true"},
+			input:    `<iframe src="http://www.youtube.com/embed/abc"></iframe>`,
+			excludes: []string{"src="},
+		},
+		{
+			name:     "iframe src restricted to configured hosts",
+			env:      map[string]string{"SANITIZE_ALLOW_IFRAMES": "true", "SANITIZE_IFRAME_HOSTS": "www.youtube.com"},
+			input:    `<iframe src="https://www.youtube.com/embed/abc"></iframe><iframe src="https://evil.example/embed"></iframe>`,
+			contains: []string{`src="https://www.youtube.com/embed/abc"`},
+			excludes: []string{"evil.example"},
+		},
+		{
+			name:     "iframe event handlers are stripped",
+			env:      map[string]string{"SANITIZE_ALLOW_IFRAMES": "true"},
+			input:    `<iframe src="https://www.youtube.com/embed/abc" onload="alert(1)"></iframe>`,
+			excludes: []string{"onload", "alert"},
+		},
+		{
+			name:     "extra elements",
+			env:      map[string]string{"SANITIZE_EXTRA_ELEMENTS": "figure, figcaption"},
+			input:    `<figure><figcaption>Caption</figcaption></figure>`,
+			contains: []string{"<figure><figcaption>Caption</figcaption></figure>"},
+		},
+		{
+			name:     "extra attribute scoped to elements",
+			env:      map[string]string{"SANITIZE_EXTRA_ATTRIBUTES": "data-id:div|span"},
+			input:    `<div data-id="1">a</div><span data-id="2">b</span><p data-id="3">c</p>`,
+			contains: []string{`<div data-id="1">`, `<span data-id="2">`, "<p>c</p>"},
+		},
+		{
+			name:     "extra attribute allowed globally",
+			env:      map[string]string{"SANITIZE_EXTRA_ATTRIBUTES": "title"},
+			input:    `<p title="Hello">a</p><h2 title="World">b</h2>`,
+			contains: []string{`<p title="Hello">`, `<h2 title="World">`},
+		},
+		{
+			name:     "event handler attributes are never allowed",
+			env:      map[string]string{"SANITIZE_EXTRA_ATTRIBUTES": "onclick,onmouseover:p"},
+			input:    `<p onclick="alert(1)" onmouseover="alert(2)">a</p>`,
+			contains: []string{"<p>a</p>"},
+			excludes: []string{"alert"},
+		},
+		{
+			name:     "scripts are always removed",
+			env:      map[string]string{"SANITIZE_EXTRA_ELEMENTS": "figure"},
+			input:    `<p>a</p><script>alert(1)</script>`,
+			contains: []string{"<p>a</p>"},
+			excludes: []string{"script", "alert"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got := buildSanitizePolicy().Sanitize(tt.input)
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Sanitize(%q) = %q, want it to contain %q", tt.input, got, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("Sanitize(%q) = %q, want it not to contain %q", tt.input, got, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffRemovedTags(t *testing.T) {
+	original := `<p>a</p><script>x</script><script>y</script><iframe></iframe>`
+	sanitized := `<p>a</p>`
+
+	got := DiffRemovedTags(original, sanitized)
+	want := []RemovedTag{{Tag: "iframe", Count: 1}, {Tag: "script", Count: 2}}
+	if len(got) != len(want) {
+		t.Fatalf("DiffRemovedTags() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DiffRemovedTags()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// benchmarkHTML is a typical rich text body from the editor
+var benchmarkHTML = strings.Repeat(`<h2>Tournament results</h2><p class="ql-align-justify">The <strong>Sentul Open</strong> finished on <em>Sunday</em> with a <a href="https://example.com/results">record score</a>.</p><ul><li>First</li><li>Second</li></ul><script>alert(1)</script>`, 20)
+
+// BenchmarkSanitizeHTML compares the shared policy with building a new
+// policy for every call, as SanitizeHTML used to
+func BenchmarkSanitizeHTML(b *testing.B) {
+	b.Run("shared policy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			SanitizeHTML(benchmarkHTML)
+		}
+	})
+	b.Run("policy per call", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buildSanitizePolicy().Sanitize(benchmarkHTML)
+		}
+	})
+}
+
+func TestBuildContentImageHosts(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		t.Setenv("SANITIZE_RESTRICT_IMAGES", "false")
+		if hosts := buildContentImageHosts(); hosts != nil {
+			t.Errorf("buildContentImageHosts() = %v, want nil", hosts)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Setenv("SANITIZE_RESTRICT_IMAGES", "true")
+		t.Setenv("BASE_URL", "https://API.example.com")
+		t.Setenv("SANITIZE_IMAGE_HOSTS", "cdn.example.com, Images.example.org")
+
+		hosts := buildContentImageHosts()
+		for _, host := range []string{"api.example.com", "cdn.example.com", "images.example.org"} {
+			if !hosts[host] {
+				t.Errorf("buildContentImageHosts() = %v, want it to contain %q", hosts, host)
+			}
+		}
+	})
+}
+
+func TestRestrictContentImages(t *testing.T) {
+	hosts := map[string]bool{"api.example.com": true}
+
+	tests := []struct {
+		name    string
+		hosts   map[string]bool
+		content string
+		want    string
+	}{
+		{
+			name:    "restriction off",
+			content: `<img src="https://tracker.example/pixel.gif">`,
+			want:    `<img src="https://tracker.example/pixel.gif">`,
+		},
+		{
+			name:    "own upload",
+			hosts:   hosts,
+			content: `<p><img src="/uploads/content/a.png" alt="A"></p>`,
+			want:    `<p><img src="/uploads/content/a.png" alt="A"></p>`,
+		},
+		{
+			name:    "allowed host",
+			hosts:   hosts,
+			content: `<img src="https://API.example.com/uploads/a.png"/>`,
+			want:    `<img src="https://API.example.com/uploads/a.png"/>`,
+		},
+		{
+			name:    "other host",
+			hosts:   hosts,
+			content: `<p>a<img src="https://tracker.example/pixel.gif">b</p>`,
+			want:    `<p>ab</p>`,
+		},
+		{
+			name:    "relative path outside uploads",
+			hosts:   hosts,
+			content: `<img src="/admin/pixel.gif">`,
+			want:    ``,
+		},
+		{
+			name:    "links to other hosts are kept",
+			hosts:   hosts,
+			content: `<a href="https://tracker.example/" rel="nofollow">x</a> &amp; <img src="https://tracker.example/p.gif">`,
+			want:    `<a href="https://tracker.example/" rel="nofollow">x</a> &amp; `,
+		},
+		{
+			name:    "escaped src",
+			hosts:   hosts,
+			content: `<img src="https://api.example.com/uploads/a.png?x=1&amp;y=2">`,
+			want:    `<img src="https://api.example.com/uploads/a.png?x=1&amp;y=2">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := restrictContentImages(tt.content, tt.hosts); got != tt.want {
+				t.Errorf("restrictContentImages(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
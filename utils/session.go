@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"sentul-golf-be/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrSessionNotFound is returned when a session doesn't exist or has ended
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session describes an issued login token so admins can review and revoke it
+type Session struct {
+	ID        string    `json:"id"` // Token ID (JTI)
+	UserID    string    `json:"user_id"`
+	Client    string    `json:"client"` // User-Agent of the login or refresh request
+	IP        string    `json:"ip"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// sessionKey returns the Redis key holding a session of a user
+func sessionKey(userID, jti string) string {
+	return BuildCacheKey("auth", "session", userID, jti)
+}
+
+// trustedProxies are the reverse proxies whose forwarding headers ClientIP believes
+var trustedProxies []*net.IPNet
+
+// LoadProxyConfig reads TRUSTED_PROXIES, a comma-separated list of IP addresses
+// or CIDR ranges of the reverse proxies in front of the API. An entry that is
+// neither is an error.
+func LoadProxyConfig() error {
+	var proxies []*net.IPNet
+	for _, entry := range strings.Split(config.GetEnv("TRUSTED_PROXIES", ""), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return fmt.Errorf("TRUSTED_PROXIES: %q is not an IP address or CIDR range", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return fmt.Errorf("TRUSTED_PROXIES: %q is not an IP address or CIDR range", entry)
+		}
+		proxies = append(proxies, network)
+	}
+	trustedProxies = proxies
+	return nil
+}
+
+// isTrustedProxy reports whether ip belongs to one of the trusted proxies
+func isTrustedProxy(ip net.IP) bool {
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP address of the client that sent the request.
+// Forwarding headers are only believed when the request came from a trusted
+// proxy: the right-most X-Forwarded-For entry that isn't a trusted proxy is
+// the client, falling back to X-Real-IP. Anyone else gets their RemoteAddr,
+// so clients can't pick their own IP by sending the headers.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remote := net.ParseIP(host)
+	if remote == nil || !isTrustedProxy(remote) {
+		return host
+	}
+
+	// Walk X-Forwarded-For from the proxy nearest to us outwards
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	client := ""
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			break
+		}
+		client = ip.String()
+		if !isTrustedProxy(ip) {
+			return client
+		}
+	}
+	if client != "" {
+		// Every hop was one of our proxies, or the rest of the header is garbage
+		return client
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return host
+}
+
+// TrackSession records the token described by claims as a session of its
+// user. It is kept for as long as the token can still be used or refreshed.
+// Does nothing when Redis is unavailable.
+func TrackSession(ctx context.Context, claims *Claims, r *http.Request) error {
+	if !IsRedisAvailable() || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	session := Session{
+		ID:        claims.ID,
+		UserID:    claims.UserID,
+		Client:    r.UserAgent(),
+		IP:        ClientIP(r),
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	if claims.IssuedAt != nil {
+		session.IssuedAt = claims.IssuedAt.Time
+	}
+
+	return CacheSet(ctx, sessionKey(claims.UserID, claims.ID), session, time.Until(session.ExpiresAt)+RefreshGrace)
+}
+
+// UntrackSession forgets a session, e.g. after its token was revoked
+func UntrackSession(ctx context.Context, userID, jti string) error {
+	return CacheDelete(ctx, sessionKey(userID, jti))
+}
+
+// ListSessions returns the active sessions of a user, newest first
+func ListSessions(ctx context.Context, userID string) ([]Session, error) {
+	if !IsRedisAvailable() {
+		return nil, errors.New("redis not available")
+	}
+
+	client := config.GetRedis()
+	sessions := []Session{}
+
+	// Use SCAN to find all sessions of the user
+	var cursor uint64
+	for {
+		keys, next, err := client.Scan(ctx, cursor, sessionKey(userID, "*"), 100).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			var session Session
+			if err := CacheGet(ctx, key, &session); err != nil {
+				continue // Expired between SCAN and GET
+			}
+			if !IsTokenRevoked(ctx, session.ID) {
+				sessions = append(sessions, session)
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].IssuedAt.After(sessions[j].IssuedAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession revokes the token behind a session of a user and forgets the session
+func RevokeSession(ctx context.Context, userID, jti string) error {
+	if !IsRedisAvailable() {
+		return errors.New("redis not available")
+	}
+
+	var session Session
+	if err := CacheGet(ctx, sessionKey(userID, jti), &session); err != nil {
+		return ErrSessionNotFound
+	}
+
+	claims := &Claims{
+		UserID: session.UserID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        session.ID,
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
+		},
+	}
+	if err := RevokeToken(ctx, claims); err != nil {
+		return err
+	}
+
+	return UntrackSession(ctx, userID, jti)
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoadProxyConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{name: "unset", value: "", want: 0},
+		{name: "single IPv4", value: "10.0.0.1", want: 1},
+		{name: "single IPv6", value: "::1", want: 1},
+		{name: "CIDR ranges with blanks", value: "10.0.0.0/8, ,fd00::/8", want: 2},
+		{name: "hostname", value: "proxy.local", wantErr: true},
+		{name: "bad CIDR", value: "10.0.0.0/33", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRUSTED_PROXIES", tt.value)
+			t.Cleanup(func() { trustedProxies = nil })
+
+			err := LoadProxyConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadProxyConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(trustedProxies) != tt.want {
+				t.Errorf("loaded %d proxies, want %d", len(trustedProxies), tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8,192.168.1.1")
+	if err := LoadProxyConfig(); err != nil {
+		t.Fatalf("LoadProxyConfig() error = %v", err)
+	}
+	t.Cleanup(func() { trustedProxies = nil })
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		realIP    string
+		want      string
+	}{
+		{name: "direct client", remote: "203.0.113.7:5000", want: "203.0.113.7"},
+		{name: "untrusted peer headers ignored", remote: "203.0.113.7:5000", forwarded: []string{"1.2.3.4"}, realIP: "1.2.3.4", want: "203.0.113.7"},
+		{name: "remote without port", remote: "203.0.113.7", want: "203.0.113.7"},
+		{name: "trusted proxy without headers", remote: "10.1.2.3:5000", want: "10.1.2.3"},
+		{name: "trusted proxy forwards client", remote: "10.1.2.3:5000", forwarded: []string{"203.0.113.7"}, want: "203.0.113.7"},
+		{name: "spoofed left-most entry skipped", remote: "10.1.2.3:5000", forwarded: []string{"1.2.3.4, 203.0.113.7"}, want: "203.0.113.7"},
+		{name: "proxy chain skipped", remote: "10.1.2.3:5000", forwarded: []string{"203.0.113.7, 192.168.1.1, 10.9.9.9"}, want: "203.0.113.7"},
+		{name: "repeated headers", remote: "10.1.2.3:5000", forwarded: []string{"1.2.3.4", "203.0.113.7, 10.9.9.9"}, want: "203.0.113.7"},
+		{name: "every hop trusted", remote: "10.1.2.3:5000", forwarded: []string{"10.5.5.5, 10.9.9.9"}, want: "10.5.5.5"},
+		{name: "garbage stops the walk", remote: "10.1.2.3:5000", forwarded: []string{"203.0.113.7, bogus, 10.9.9.9"}, want: "10.9.9.9"},
+		{name: "X-Real-IP fallback", remote: "10.1.2.3:5000", realIP: "203.0.113.7", want: "203.0.113.7"},
+		{name: "invalid X-Real-IP", remote: "10.1.2.3:5000", realIP: "bogus", want: "10.1.2.3"},
+		{name: "IPv6 client", remote: "192.168.1.1:5000", forwarded: []string{"2001:db8::1"}, want: "2001:db8::1"},
+		{name: "single IP entry is exact", remote: "192.168.1.2:5000", forwarded: []string{"203.0.113.7"}, want: "192.168.1.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remote
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			if got := ClientIP(r); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
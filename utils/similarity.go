@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// shingleSize is the number of consecutive words in a shingle
+const shingleSize = 3
+
+// ContentShingles returns the set of lowercased word shingles (runs of
+// shingleSize consecutive words) of HTML content. Content shorter than a
+// shingle becomes a single shingle.
+func ContentShingles(html string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(htmlToText(html)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	shingles := make(map[string]struct{})
+	if len(words) == 0 {
+		return shingles
+	}
+	if len(words) < shingleSize {
+		shingles[strings.Join(words, " ")] = struct{}{}
+		return shingles
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		shingles[strings.Join(words[i:i+shingleSize], " ")] = struct{}{}
+	}
+	return shingles
+}
+
+// ShingleSimilarity returns the Jaccard similarity of two shingle sets, from
+// 0 (nothing in common) to 1 (identical). Two empty sets score 0.
+func ShingleSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	// Iterate over the smaller set
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// ContentSimilarity estimates how similar two HTML bodies are, from 0 to 1
+func ContentSimilarity(a, b string) float64 {
+	return ShingleSimilarity(ContentShingles(a), ContentShingles(b))
+}
@@ -1,14 +1,54 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
 )
 
-// GenerateSlug generates a URL-friendly slug from a title
+// idLikePattern matches values that could be mistaken for a record ID:
+// a CUID ("c" followed by 24 lowercase alphanumerics) or a purely numeric ID
+var idLikePattern = regexp.MustCompile(`^(?:c[0-9a-z]{24}|[0-9]+)$`)
+
+// IsIDLike reports whether a slug has the same shape as a record ID
+// and would therefore be ambiguous with ID-based routes
+func IsIDLike(slug string) bool {
+	return idLikePattern.MatchString(slug)
+}
+
+// slugLetterReplacer spells out letters that don't decompose into an ASCII
+// letter plus accents
+var slugLetterReplacer = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "đ", "d", "ð", "d",
+	"ł", "l", "þ", "th", "ı", "i",
+)
+
+// transliterate turns accented Latin letters into plain ASCII (ä→a, é→e,
+// ß→ss). Other scripts are left alone.
+func transliterate(s string) string {
+	s = slugLetterReplacer.Replace(s)
+
+	// Split letters from their accents, then drop the accents
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// GenerateSlug generates a URL-friendly slug from a title. Accented Latin
+// letters are transliterated; other characters (CJK, emoji, ...) are dropped,
+// so the slug may be empty.
 func GenerateSlug(title string) string {
-	// Convert to lowercase
-	slug := strings.ToLower(title)
+	// Convert to lowercase and transliterate accented letters
+	slug := transliterate(strings.ToLower(title))
 	
 	// Replace spaces and special characters with hyphens
 	slug = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(slug, "-")
@@ -21,3 +61,48 @@ func GenerateSlug(title string) string {
 	
 	return slug
 }
+
+// nonIDSlug adds a "-post" suffix to a generated slug that looks like an ID
+// (e.g. from the title "2025"), so it can't be confused with ID-based routes
+func nonIDSlug(slug string) string {
+	if IsIDLike(slug) {
+		return slug + "-post"
+	}
+	return slug
+}
+
+// maxSlugSuffix bounds the search for a free "-N" slug suffix
+const maxSlugSuffix = 1000
+
+// GenerateUniqueSlug returns base, or base with the lowest free "-N" suffix
+// ("-2", "-3", ...), so that no row of table uses it. Soft-deleted rows count
+// as taken because they still hold the unique index. An ID-like base gets a
+// "-post" suffix first.
+func GenerateUniqueSlug(db *gorm.DB, table, base string) (string, error) {
+	return UniqueSlugExcluding(db, table, base, "")
+}
+
+// UniqueSlugExcluding works like GenerateUniqueSlug but ignores the row
+// excludeID, e.g. the row whose slug is being changed
+func UniqueSlugExcluding(db *gorm.DB, table, base, excludeID string) (string, error) {
+	base = nonIDSlug(base)
+	for i := 1; i <= maxSlugSuffix; i++ {
+		slug := base
+		if i > 1 {
+			slug = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		var count int64
+		query := db.Table(table).Where("slug = ?", slug)
+		if excludeID != "" {
+			query = query.Where("id <> ?", excludeID)
+		}
+		if err := query.Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return slug, nil
+		}
+	}
+	return "", errors.New("no free slug found")
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestGenerateSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		// Single-word titles
+		{"Tournament", "tournament"},
+		{"GOLF", "golf"},
+		{"abc123", "abc123"},
+		{"2025", "2025"},
+		{"  Sentul!  ", "sentul"},
+
+		// Separators
+		{"Sentul Golf Club", "sentul-golf-club"},
+		{"Hole #7 -- Par 3", "hole-7-par-3"},
+		{"--Already--slugged--", "already-slugged"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := GenerateSlug(tt.title); got != tt.want {
+				t.Errorf("GenerateSlug(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNonIDSlug(t *testing.T) {
+	tests := []struct {
+		slug string
+		want string
+	}{
+		{"2025", "2025-post"},
+		{"42", "42-post"},
+		{"cabcdefghijklmnopqrstuvwx", "cabcdefghijklmnopqrstuvwx-post"}, // CUID shape
+		{"tournament", "tournament"},
+		{"abc123", "abc123"},
+		{"2025-open", "2025-open"},
+		{"cabcdefghijklmnopqrstuvw", "cabcdefghijklmnopqrstuvw"}, // 23 characters after the c
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			if got := nonIDSlug(tt.slug); got != tt.want {
+				t.Errorf("nonIDSlug(%q) = %q, want %q", tt.slug, got, tt.want)
+			}
+			if IsIDLike(nonIDSlug(tt.slug)) {
+				t.Errorf("nonIDSlug(%q) is still ID-like", tt.slug)
+			}
+		})
+	}
+}
+
+// slugRow is a minimal table with a unique slug, like news and events
+type slugRow struct {
+	ID        string `gorm:"primaryKey"`
+	Slug      string `gorm:"uniqueIndex"`
+	DeletedAt gorm.DeletedAt
+}
+
+func TestUniqueSlugExcluding(t *testing.T) {
+	// taken builds the rows "base", "base-2", ..., "base-n"
+	taken := func(base string, n int) []slugRow {
+		rows := []slugRow{{ID: base, Slug: base}}
+		for i := 2; i <= n; i++ {
+			slug := fmt.Sprintf("%s-%d", base, i)
+			rows = append(rows, slugRow{ID: slug, Slug: slug})
+		}
+		return rows
+	}
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	tests := []struct {
+		name      string
+		existing  []slugRow
+		base      string
+		excludeID string
+		want      string
+		wantErr   bool
+	}{
+		{name: "free", base: "opening-day", want: "opening-day"},
+		{name: "taken", existing: taken("opening-day", 1), base: "opening-day", want: "opening-day-2"},
+		{name: "lowest free suffix", existing: append(taken("opening-day", 3), slugRow{ID: "5", Slug: "opening-day-5"}), base: "opening-day", want: "opening-day-4"},
+		{name: "soft-deleted row counts as taken", existing: []slugRow{{ID: "1", Slug: "opening-day", DeletedAt: deleted}}, base: "opening-day", want: "opening-day-2"},
+		{name: "excluded row", existing: taken("opening-day", 1), base: "opening-day", excludeID: "opening-day", want: "opening-day"},
+		{name: "id-like base", existing: []slugRow{{ID: "1", Slug: "2025-post"}}, base: "2025", want: "2025-post-2"},
+		{name: "last suffix", existing: taken("golf", maxSlugSuffix-1), base: "golf", want: fmt.Sprintf("golf-%d", maxSlugSuffix)},
+		{name: "all suffixes taken", existing: taken("golf", maxSlugSuffix), base: "golf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t, &slugRow{})
+			if len(tt.existing) > 0 {
+				if err := db.CreateInBatches(tt.existing, 200).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := UniqueSlugExcluding(db, "slug_rows", tt.base, tt.excludeID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UniqueSlugExcluding() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("UniqueSlugExcluding() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSlugTransliteration(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		// Accented Latin letters
+		{"Café Crème", "cafe-creme"},
+		{"Über Straße", "uber-strasse"},
+		{"Ærø Œuvre", "aero-oeuvre"},
+		{"Łódź Þing", "lodz-thing"},
+		{"Ñandú Açaí", "nandu-acai"},
+		{"İstanbul Open", "istanbul-open"},
+
+		// Other scripts are dropped
+		{"高尔夫 Open", "open"},
+		{"ゴルフ大会", ""},
+		{"골프 2025", "2025"},
+
+		// Emoji are dropped
+		{"Golf ⛳ Day 🏌️", "golf-day"},
+		{"🎉🎉", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := GenerateSlug(tt.title); got != tt.want {
+				t.Errorf("GenerateSlug(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
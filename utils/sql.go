@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DateBuckets are the allowed time bucket sizes for grouped date queries
+var DateBuckets = map[string]bool{
+	"day":   true,
+	"week":  true,
+	"month": true,
+}
+
+// DateTruncExpr returns a SQL expression that truncates column to the start of
+// the given bucket (weeks start on Monday) for the connected SQL dialect.
+// bucket must be one of DateBuckets and column must be a trusted identifier.
+func DateTruncExpr(db *gorm.DB, bucket, column string) string {
+	switch db.Dialector.Name() {
+	case "mysql":
+		switch bucket {
+		case "week":
+			return fmt.Sprintf("DATE(DATE_SUB(%s, INTERVAL WEEKDAY(%s) DAY))", column, column)
+		case "month":
+			return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-01')", column)
+		default:
+			return fmt.Sprintf("DATE(%s)", column)
+		}
+	case "sqlite":
+		switch bucket {
+		case "week":
+			return fmt.Sprintf("DATE(%s, 'weekday 0', '-6 days')", column)
+		case "month":
+			return fmt.Sprintf("STRFTIME('%%Y-%%m-01', %s)", column)
+		default:
+			return fmt.Sprintf("DATE(%s)", column)
+		}
+	default:
+		// PostgreSQL
+		return fmt.Sprintf("DATE_TRUNC('%s', %s)", bucket, column)
+	}
+}
+
+// likeEscaper escapes LIKE wildcards so user input is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsPattern returns a lowercase LIKE pattern matching values that contain
+// term. Use it with "LOWER(column) LIKE ? ESCAPE '\'".
+func ContainsPattern(term string) string {
+	return "%" + likeEscaper.Replace(strings.ToLower(term)) + "%"
+}
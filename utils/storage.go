@@ -0,0 +1,303 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"sentul-golf-be/config"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// Storage stores uploaded files. Keys are relative to the uploads root,
+// e.g. "news/abc.jpg", and every URL a storage returns contains "/uploads/"
+// followed by the key so stored URLs can be mapped back to their file.
+type Storage interface {
+	// Save stores the content under key and returns its URL
+	Save(ctx context.Context, key string, content io.Reader, size int64, contentType string) (string, error)
+	// Delete removes the file behind a URL returned by Save. Missing files are not an error.
+	Delete(ctx context.Context, fileURL string) error
+	// URL returns the URL of the file stored under key
+	URL(key string) string
+	// List returns the files whose key starts with prefix (e.g. "news/")
+	List(ctx context.Context, prefix string) ([]StoredObject, error)
+}
+
+// StoredObject is a file found in storage
+type StoredObject struct {
+	Key     string
+	ModTime time.Time
+}
+
+// ErrStorageFull is returned by Save when the uploads disk has no space left
+var ErrStorageFull = errors.New("server storage is full")
+
+// ErrStorageFailed is wrapped by the errors Save returns when the backend
+// itself fails (disk, network, bucket), as opposed to a problem with the file
+var ErrStorageFailed = errors.New("failed to store file")
+
+// isDiskFull reports whether err means the disk or the disk quota is full
+func isDiskFull(err error) bool {
+	return errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EDQUOT)
+}
+
+// storage is the active storage backend, local disk unless InitStorage selects another
+var storage Storage = localStorage{dir: UploadDir}
+
+// remoteStorageURL is the public base URL of a remote storage backend, used to
+// recognise its absolute URLs. Empty for local storage.
+var remoteStorageURL string
+
+// InitStorage selects the storage backend from STORAGE_DRIVER (local or s3)
+func InitStorage() error {
+	switch driver := config.GetEnv("STORAGE_DRIVER", "local"); driver {
+	case "local":
+		storage = localStorage{dir: UploadDir}
+		remoteStorageURL = ""
+		return nil
+	case "s3":
+		s3, err := newS3Storage()
+		if err != nil {
+			return err
+		}
+		storage = s3
+		remoteStorageURL = s3.publicURL
+		return nil
+	default:
+		return fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+	}
+}
+
+// storageKey maps a stored file URL or path back to its key. Returns "" when
+// the URL doesn't point into the uploads root.
+func storageKey(fileURL string) string {
+	idx := strings.Index(fileURL, "/uploads/")
+	if idx == -1 {
+		return ""
+	}
+	// Clean the key so it can't escape the uploads root
+	key := strings.TrimPrefix(path.Clean("/"+fileURL[idx+len("/uploads/"):]), "/")
+	if key == "." {
+		return ""
+	}
+	return key
+}
+
+// isRemoteStorageURL reports whether fileURL is an absolute URL of the remote storage
+func isRemoteStorageURL(fileURL string) bool {
+	return remoteStorageURL != "" && strings.HasPrefix(fileURL, remoteStorageURL+"/")
+}
+
+// localStorage keeps files on local disk below dir, served under /uploads/
+type localStorage struct {
+	dir string
+}
+
+func (s localStorage) Save(ctx context.Context, key string, content io.Reader, size int64, contentType string) (string, error) {
+	fullPath := filepath.Join(s.dir, filepath.FromSlash(key))
+
+	// Create the directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		if isDiskFull(err) {
+			return "", ErrStorageFull
+		}
+		return "", fmt.Errorf("%w: create upload directory: %v", ErrStorageFailed, err)
+	}
+
+	// Create the file
+	dst, err := os.Create(fullPath)
+	if err != nil {
+		if isDiskFull(err) {
+			return "", ErrStorageFull
+		}
+		return "", fmt.Errorf("%w: create file: %v", ErrStorageFailed, err)
+	}
+
+	// Copy the content to the destination. Close can report a full disk too,
+	// when the last buffered bytes are flushed.
+	_, err = io.Copy(dst, content)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Clean up the partial file
+		os.Remove(fullPath)
+		if isDiskFull(err) {
+			return "", ErrStorageFull
+		}
+		return "", fmt.Errorf("%w: write file: %v", ErrStorageFailed, err)
+	}
+
+	return s.URL(key), nil
+}
+
+func (s localStorage) Delete(ctx context.Context, fileURL string) error {
+	key := storageKey(fileURL)
+	if key == "" {
+		return nil
+	}
+	filePath := filepath.Join(s.dir, filepath.FromSlash(key))
+
+	// Check if file exists
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil // File doesn't exist, consider it as success
+	}
+
+	// Delete the file
+	if err := os.Remove(filePath); err != nil {
+		return errors.New("failed to delete image file")
+	}
+
+	return nil
+}
+
+func (s localStorage) URL(key string) string {
+	return "/uploads/" + key
+}
+
+func (s localStorage) List(ctx context.Context, prefix string) ([]StoredObject, error) {
+	var objects []StoredObject
+	root := filepath.Join(s.dir, filepath.FromSlash(prefix))
+	err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil // Nothing uploaded under prefix yet
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, StoredObject{Key: filepath.ToSlash(rel), ModTime: info.ModTime()})
+		return nil
+	})
+	return objects, err
+}
+
+// s3Storage keeps files in an S3-compatible bucket (AWS S3, MinIO, ...)
+// below the "uploads/" prefix
+type s3Storage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+// newS3Storage creates the S3 backend from the S3_* environment variables
+func newS3Storage() (*s3Storage, error) {
+	endpoint := config.GetEnv("S3_ENDPOINT", "")
+	bucket := config.GetEnv("S3_BUCKET", "")
+	if endpoint == "" || bucket == "" {
+		return nil, errors.New("S3_ENDPOINT and S3_BUCKET are required for the s3 storage driver")
+	}
+	useSSL := config.GetEnv("S3_USE_SSL", "true") == "true"
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.GetEnv("S3_ACCESS_KEY", ""), config.GetEnv("S3_SECRET_KEY", ""), ""),
+		Secure: useSSL,
+		Region: config.GetEnv("S3_REGION", ""),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	// Default to path-style URLs on the endpoint itself
+	publicURL := strings.TrimSuffix(config.GetEnv("S3_PUBLIC_URL", ""), "/")
+	if publicURL == "" {
+		scheme := "https"
+		if !useSSL {
+			scheme = "http"
+		}
+		publicURL = fmt.Sprintf("%s://%s/%s", scheme, endpoint, bucket)
+	}
+
+	return &s3Storage{client: client, bucket: bucket, publicURL: publicURL}, nil
+}
+
+func (s *s3Storage) Save(ctx context.Context, key string, content io.Reader, size int64, contentType string) (string, error) {
+	_, err := s.client.PutObject(ctx, s.bucket, "uploads/"+key, content, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: put object: %v", ErrStorageFailed, err)
+	}
+	return s.URL(key), nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, fileURL string) error {
+	key := storageKey(fileURL)
+	if key == "" {
+		return nil
+	}
+	// S3 reports success for objects that don't exist
+	if err := s.client.RemoveObject(ctx, s.bucket, "uploads/"+key, minio.RemoveObjectOptions{}); err != nil {
+		return errors.New("failed to delete image file")
+	}
+	return nil
+}
+
+func (s *s3Storage) URL(key string) string {
+	return s.publicURL + "/uploads/" + key
+}
+
+func (s *s3Storage) List(ctx context.Context, prefix string) ([]StoredObject, error) {
+	var objects []StoredObject
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    "uploads/" + prefix,
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		objects = append(objects, StoredObject{
+			Key:     strings.TrimPrefix(object.Key, "uploads/"),
+			ModTime: object.LastModified,
+		})
+	}
+	return objects, nil
+}
+
+// StoredFile describes where an uploaded file is kept, for troubleshooting
+type StoredFile struct {
+	URL    string `json:"url"`              // As stored in the database
+	Key    string `json:"key"`              // Key relative to the uploads root, "" if the URL isn't an upload
+	Path   string `json:"path,omitempty"`   // Resolved file path, local storage only
+	Exists *bool  `json:"exists,omitempty"` // Whether the file is on disk, local storage only
+}
+
+// DescribeStoredFile resolves the storage key of a stored file URL and, for
+// local storage, its path on disk and whether it exists
+func DescribeStoredFile(fileURL string) StoredFile {
+	file := StoredFile{URL: fileURL, Key: storageKey(fileURL)}
+	local, ok := storage.(localStorage)
+	if !ok || file.Key == "" {
+		return file
+	}
+
+	path := filepath.Join(local.dir, filepath.FromSlash(file.Key))
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	_, err := os.Stat(path)
+	exists := err == nil
+	file.Path = path
+	file.Exists = &exists
+	return file
+}
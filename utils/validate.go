@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"errors"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// maxEmailLength is the longest email address allowed by RFC 5321
+const maxEmailLength = 254
+
+// errInvalidEmail is returned by ValidateEmail for malformed addresses
+var errInvalidEmail = errors.New("must be a valid email address")
+
+// ValidateEmail checks that email is a plain address like "name@example.com",
+// without a display name or angle brackets, on a domain with a dot
+func ValidateEmail(email string) error {
+	if email == "" || len(email) > maxEmailLength {
+		return errInvalidEmail
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return errInvalidEmail
+	}
+
+	at := strings.LastIndex(email, "@")
+	domain := email[at+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") || strings.Contains(domain, "..") {
+		return errInvalidEmail
+	}
+	return nil
+}
+
+// maxURLLength is the longest URL accepted in content fields
+const maxURLLength = 2048
+
+// errInvalidURL is returned by ValidateHTTPURL for anything but an absolute http(s) URL
+var errInvalidURL = errors.New("must be an absolute http or https URL")
+
+// ValidateHTTPURL checks that raw is an absolute http(s) URL with a host,
+// e.g. "https://example.com/article"
+func ValidateHTTPURL(raw string) error {
+	if raw == "" || len(raw) > maxURLLength {
+		return errInvalidURL
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errInvalidURL
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		valid bool
+	}{
+		{"name@example.com", true},
+		{"first.last+tag@mail.example.co.id", true},
+		{"user_1@sub-domain.example.org", true},
+		{"", false},
+		{"name", false},
+		{"name@", false},
+		{"@example.com", false},
+		{"name@localhost", false},
+		{"name@.example.com", false},
+		{"name@example.com.", false},
+		{"name@example..com", false},
+		{"Name <name@example.com>", false},
+		{"<name@example.com>", false},
+		{" name@example.com", false},
+		{"name@example.com ", false},
+		{"na me@example.com", false},
+		{"name@@example.com", false},
+		{"name@example.com,other@example.com", false},
+		{strings.Repeat("a", 64) + "@" + strings.Repeat("b", 185) + ".com", true}, // 254 characters
+		{strings.Repeat("a", 64) + "@" + strings.Repeat("b", 186) + ".com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			err := ValidateEmail(tt.email)
+			if (err == nil) != tt.valid {
+				t.Errorf("ValidateEmail(%q) error = %v, want valid %v", tt.email, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		valid    bool
+	}{
+		{"letters and digits", "golf2025", true},
+		{"multibyte letters", "sëntül12", true},
+		{"too short", "golf123", false},
+		{"letters only", "sentulgolf", false},
+		{"digits only", "12345678", false},
+		{"at the bcrypt limit", strings.Repeat("a", 71) + "1", true},
+		{"over the bcrypt limit", strings.Repeat("a", 72) + "1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePassword(tt.password)
+			if (err == nil) != tt.valid {
+				t.Errorf("ValidatePassword(%q) error = %v, want valid %v", tt.password, err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"sentul-golf-be/config"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// viewCountTables maps view-tracked entities to their tables
+var viewCountTables = map[string]string{
+	"news":  "news",
+	"event": "events",
+}
+
+// viewsPendingKey is the Redis set of "<entity>:<id>" members with unflushed views
+const viewsPendingKey = "views:pending"
+
+// viewFlushBatchSize is the number of counters written to the DB per transaction
+const viewFlushBatchSize = 100
+
+// viewCountKey returns the Redis counter key for an entity
+func viewCountKey(member string) string {
+	return "views:count:" + member
+}
+
+// IncrementViewCount records a single view of an entity. Views are counted in
+// Redis and flushed to the view_count column periodically; when Redis is
+// unavailable the DB column is incremented directly.
+func IncrementViewCount(ctx context.Context, entity, id string) {
+	if _, ok := viewCountTables[entity]; !ok || id == "" {
+		return
+	}
+
+	if IsRedisAvailable() {
+		member := entity + ":" + id
+		client := config.GetRedis()
+		_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Incr(ctx, viewCountKey(member))
+			pipe.SAdd(ctx, viewsPendingKey, member)
+			return nil
+		})
+		if err == nil {
+			return
+		}
+		log.Printf("Warning: Failed to count view in Redis, writing to DB: %v", err)
+	}
+
+	if err := addViewCount(config.GetDB(), entity, id, 1); err != nil {
+		log.Printf("Warning: Failed to increment view count for %s %s: %v", entity, id, err)
+	}
+}
+
+// addViewCount adds n views to the view_count column of an entity
+func addViewCount(db *gorm.DB, entity, id string, n int64) error {
+	return db.Table(viewCountTables[entity]).
+		Where("id = ?", id).
+		UpdateColumn("view_count", gorm.Expr("view_count + ?", n)).Error
+}
+
+// FlushViewCounts writes the view counts accumulated in Redis to the DB in
+// batches and resets the flushed counters
+func FlushViewCounts(ctx context.Context) error {
+	if !IsRedisAvailable() {
+		return nil
+	}
+
+	client := config.GetRedis()
+	db := config.GetDB()
+
+	for {
+		// Take a batch of pending counters off the set
+		members, err := client.SPopN(ctx, viewsPendingKey, viewFlushBatchSize).Result()
+		if err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+
+		// Read and reset each counter atomically; views arriving afterwards
+		// re-add the member to the pending set
+		counts := make(map[string]int64, len(members))
+		for _, member := range members {
+			val, err := client.GetDel(ctx, viewCountKey(member)).Result()
+			if err != nil {
+				continue
+			}
+			if n, err := strconv.ParseInt(val, 10, 64); err == nil && n > 0 {
+				counts[member] = n
+			}
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for member, n := range counts {
+				entity, id, _ := strings.Cut(member, ":")
+				if _, ok := viewCountTables[entity]; !ok {
+					continue
+				}
+				if err := addViewCount(tx, entity, id, n); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			// Put the counts back so they are retried on the next flush
+			for member, n := range counts {
+				client.IncrBy(ctx, viewCountKey(member), n)
+				client.SAdd(ctx, viewsPendingKey, member)
+			}
+			return err
+		}
+	}
+}
+
+// StartViewCountFlusher flushes view counts to the DB every interval in the background
+func StartViewCountFlusher(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := FlushViewCounts(context.Background()); err != nil {
+				log.Printf("Warning: Failed to flush view counts: %v", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sentul-golf-be/config"
+)
+
+// WebhookEvent is the JSON body posted to every webhook URL
+type WebhookEvent struct {
+	Event string `json:"event"` // e.g. "news.published" or "event.published"
+	ID    string `json:"id"`
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
+
+// webhookClient sends webhooks; each attempt has its own timeout
+var webhookClient = &http.Client{}
+
+// webhookURLs returns the endpoints from the comma-separated WEBHOOK_URLS
+func webhookURLs() []string {
+	var urls []string
+	for _, url := range strings.Split(config.GetEnv("WEBHOOK_URLS", ""), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// webhookSettings returns the per-attempt timeout (WEBHOOK_TIMEOUT, default
+// 5s) and the number of attempts per URL (WEBHOOK_ATTEMPTS, default 3)
+func webhookSettings() (time.Duration, int) {
+	timeout := 5 * time.Second
+	if d, err := time.ParseDuration(config.GetEnv("WEBHOOK_TIMEOUT", "")); err == nil && d > 0 {
+		timeout = d
+	}
+	attempts := 3
+	if n, err := strconv.Atoi(config.GetEnv("WEBHOOK_ATTEMPTS", "")); err == nil && n > 0 {
+		attempts = n
+	}
+	return timeout, attempts
+}
+
+// NotifyPublished tells the webhooks that a news article or event ("news" or
+// "event") has just been published
+func NotifyPublished(entity, id, slug, title string) {
+	SendWebhook(WebhookEvent{Event: entity + ".published", ID: id, Slug: slug, Title: title})
+}
+
+// SendWebhook posts event to every WEBHOOK_URLS endpoint in the background.
+// Failures are retried with backoff and only logged, so they never affect
+// the request that triggered them.
+func SendWebhook(event WebhookEvent) {
+	urls := webhookURLs()
+	if len(urls) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Warning: Failed to encode webhook %s: %v", event.Event, err)
+		return
+	}
+
+	timeout, attempts := webhookSettings()
+	for _, url := range urls {
+		go deliverWebhook(url, body, event.Event, timeout, attempts)
+	}
+}
+
+// deliverWebhook posts body to url until it gets a 2xx response, waiting
+// 1s, 2s, 4s... between attempts
+func deliverWebhook(url string, body []byte, event string, timeout time.Duration, attempts int) {
+	wait := time.Second
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err := postWebhook(url, body, timeout)
+		if err == nil {
+			return
+		}
+
+		log.Printf("Webhook %s to %s failed (attempt %d/%d): %v", event, url, attempt, attempts, err)
+		if attempt < attempts {
+			time.Sleep(wait)
+			wait *= 2
+		}
+	}
+}
+
+// postWebhook makes a single delivery attempt
+func postWebhook(url string, body []byte, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}